@@ -0,0 +1,154 @@
+package surrealhigh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/surrealdb.go"
+)
+
+// Batch collects statements and runs them in a single query, unlike Tx each
+// statement succeeds or fails on its own
+//
+//	b := NewBatch(db)
+//	selected := b.Add(NewQueryFrom(table))
+//	results, err := b.Run()
+//	docs, err := ResultOf[D](results, selected)
+//
+// The statements share their variables, a name used twice fails with
+// ErrDuplicateValuation.
+type Batch struct {
+	db         SurrealDriver
+	statements []Statement
+}
+
+// Slot is the position of a statement in a Batch or a Tx
+type Slot int
+
+func NewBatch(db SurrealDriver) *Batch {
+	return &Batch{db: db}
+}
+
+// Add appends the statement s to the batch
+func (b *Batch) Add(s Statement) Slot {
+	b.statements = append(b.statements, s)
+	return Slot(len(b.statements) - 1)
+}
+
+func (b *Batch) String() string {
+	return joinStatements(b.statements)
+}
+
+// Run runs the statements, it returns with the following errors
+//   - type ErrDuplicateValuation
+//   - ErrNotInThisTable, see DeleteOptionThing
+//   - any error from surrealdb.go query driver
+//   - any error from surrealdb.go unmarshal
+//
+// The errors of the statements are reported by their StatementResult.
+func (b *Batch) Run() (Results, error) {
+	return runStatements(b.db, b.String(), b.statements)
+}
+
+func joinStatements(statements []Statement) string {
+	sql := make([]string, len(statements))
+	for i, s := range statements {
+		sql[i] = s.String()
+	}
+	return strings.Join(sql, "; ")
+}
+
+// statementValidator is a Statement which options may be invalid, e.g. a
+// Delete of a thing of another table
+type statementValidator interface {
+	validate() error
+}
+
+func runStatements(db SurrealDriver, sql string, statements []Statement) (Results, error) {
+	var vars []conditionAtomVar
+	for i, s := range statements {
+		if v, ok := s.(statementValidator); ok {
+			if err := v.validate(); err != nil {
+				return nil, fmt.Errorf("statement %d: %w", i, err)
+			}
+		}
+		vars = append(vars, s.valuedVars()...)
+	}
+	raw, err := queryStatements(context.Background(), db, sql, vars)
+	if err != nil {
+		return nil, err
+	}
+	results := make(Results, len(raw))
+	for i, r := range raw {
+		var statement string
+		if i < len(statements) {
+			statement = statements[i].String()
+		}
+		results[i] = r.asStatementResult(statement)
+	}
+	return results, nil
+}
+
+// StatementResult is the result of a statement as reported by SurrealDB
+type StatementResult struct {
+	Status string
+	// Time is the server reported duration of the statement
+	Time time.Duration
+	// Err is the ErrStatement of a statement which status is not OK
+	Err error
+
+	result interface{}
+}
+
+// Results are the results of the statements in the order they were added
+type Results []StatementResult
+
+func (r statementResult) asStatementResult(statement string) StatementResult {
+	return StatementResult{
+		Status: r.Status,
+		Time:   r.duration(),
+		Err:    r.err(statement),
+		result: r.Result,
+	}
+}
+
+// duration parses the reported time of the statement such as 337.295µs
+func (r statementResult) duration() time.Duration {
+	d, _ := time.ParseDuration(r.Time)
+	return d
+}
+
+// DecodeResult decodes the result of a statement, it returns with the
+// following errors
+//   - the error of the statement
+//   - any error from surrealdb.go unmarshal
+func DecodeResult[D any](r StatementResult) ([]D, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if r.result == nil {
+		return nil, nil
+	}
+	var docs []D
+	if err := surrealdb.Unmarshal(r.result, &docs); err != nil {
+		return nil, fmt.Errorf("surrealdb: unmarshal result: %w", err)
+	}
+	return docs, nil
+}
+
+// ResultOf decodes the result of the statement at slot, it returns with the
+// following errors
+//   - ErrNoResult when there is no result at slot
+//   - any error from DecodeResult
+func ResultOf[D any](results Results, slot Slot) ([]D, error) {
+	if int(slot) < 0 || int(slot) >= len(results) {
+		return nil, fmt.Errorf("slot %d: %w", slot, ErrNoResult)
+	}
+	docs, err := DecodeResult[D](results[slot])
+	if err != nil {
+		return nil, fmt.Errorf("slot %d: %w", slot, err)
+	}
+	return docs, nil
+}
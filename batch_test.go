@@ -0,0 +1,58 @@
+package surrealhigh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch_Run(t *testing.T) {
+	db := newMockQueryDriver([]interface{}{
+		map[string]interface{}{
+			"result": []interface{}{map[string]interface{}{"RecordID": 1}},
+			"status": "OK",
+			"time":   "337.295µs",
+		},
+		map[string]interface{}{
+			"result": "Database record `records:a` already exists",
+			"status": "ERR",
+			"time":   "1.5ms",
+		},
+	})
+	b := NewBatch(db)
+	selected := b.Add(NewQueryFrom(Table("records"),
+		QueryOptionWhere(NewConditionIs(Field("record_id"), NewConditionAtomVar("rid", 1)))))
+	created := b.Add(NewCreate(mockEdge{}))
+	results, err := b.Run()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM records WHERE (record_id IS $rid); CREATE "+
+		b.statements[created].(Create).Thing().String()+" CONTENT $v_a3a30b5239afb396", *db.sql)
+	assert.Equal(t, map[string]interface{}{"rid": 1, "v_a3a30b5239afb396": mockEdge{}}, *db.vars)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "OK", results[selected].Status)
+	assert.Equal(t, 337295*time.Nanosecond, results[selected].Time)
+	assert.NoError(t, results[selected].Err)
+	docs, err := ResultOf[mockDoc](results, selected)
+	require.NoError(t, err)
+	assert.Equal(t, []mockDoc{{RecordID: 1}}, docs)
+
+	assert.Equal(t, "ERR", results[created].Status)
+	assert.Equal(t, 1500*time.Microsecond, results[created].Time)
+	assert.ErrorContains(t, results[created].Err, "already exists")
+	_, err = ResultOf[mockEdge](results, created)
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = ResultOf[mockDoc](results, Slot(2))
+	assert.ErrorIs(t, err, ErrNoResult)
+}
+
+func TestBatch_Run_duplicateValuation(t *testing.T) {
+	b := NewBatch(newMockQueryDriver(nil))
+	b.Add(NewQueryFrom(Table("a"), QueryOptionWhere(NewConditionIs(Field("a"), NewConditionAtomVar("v", 0)))))
+	b.Add(NewQueryFrom(Table("b"), QueryOptionWhere(NewConditionIs(Field("b"), NewConditionAtomVar("v", 1)))))
+	_, err := b.Run()
+	assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+}
@@ -23,6 +23,19 @@
 // in the same directory will create the file sigh_doc_record.go, in package records,
 // containing the toolkit you can use to build surrealhigh queries.
 //
+// Docs listed in -edge=likes,wrote are graph edges; their doc carries the typed
+// in and out things of the edge, see surrealhigh.Relate.
+//
+// An integer field tagged `surrealhigh:"version"` is the doc version; the doc
+// then implements surrealhigh.VersionedDoc, see surrealhigh.SwapOn.
+//
+// Each field type has typed predicates so that a field is only compared to a
+// value of its own type: Eq and Ne, Lt, Le, Gt and Ge for numbers and strings,
+// Before and After for times, Contains for arrays; e.g.
+//
+//	q := surrealhigh.NewQueryFrom(docRecord{}.Table(),
+//		surrealhigh.QueryOptionWhere(fDocRecord_T{}.After(since)))
+//
 // Adapted from https://cs.opensource.google/go/x/tools/+/refs/tags/v0.10.0:cmd/stringer/stringer.go;bpv=0
 package main
 
@@ -36,9 +49,10 @@ import (
 )
 
 var (
-	doc = flag.String("doc", "", "comma-separated list of doc struct names; must be set")
-	pkg = flag.String("pkg", "", "destination package")
-	out = flag.String("o", "", "destination file .go")
+	doc  = flag.String("doc", "", "comma-separated list of doc struct names; must be set")
+	pkg  = flag.String("pkg", "", "destination package")
+	out  = flag.String("o", "", "destination file .go")
+	edge = flag.String("edge", "", "comma-separated list of doc struct names which are graph edges")
 )
 
 func main() {
@@ -61,7 +75,12 @@ func main() {
 	}
 	tags := []string{} // build tags
 
-	if err := jennifer.NewGen(args, tags, docs, *pkg, *out); err != nil {
+	var opts []jennifer.GenOption
+	if len(*edge) > 0 {
+		opts = append(opts, jennifer.GenOptionEdges(strings.Split(*edge, ",")...))
+	}
+
+	if err := jennifer.NewGen(args, tags, docs, *pkg, *out, opts...); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -0,0 +1,61 @@
+package surrealhigh
+
+import "context"
+
+// NewSurrealDBContext adapts db to SurrealDBContext; if db is not context
+// aware its calls run in a goroutine and return ctx.Err() as soon as ctx is
+// done, note that the call itself goes on until db returns
+func NewSurrealDBContext(db SurrealDB) SurrealDBContext {
+	if dbContext, ok := db.(SurrealDBContext); ok {
+		return dbContext
+	}
+	return surrealDBContext{db}
+}
+
+// driverContext is the context aware driver of driver
+func driverContext(driver SurrealDriver) SurrealDBContext {
+	if driver, ok := driver.(SurrealDriverContext); ok {
+		return driver.DriverContext()
+	}
+	return NewSurrealDBContext(driver.Driver())
+}
+
+type surrealDBContext struct {
+	db SurrealDB
+}
+
+func (db surrealDBContext) QueryContext(ctx context.Context, sql string, vars interface{}) (interface{}, error) {
+	return withContext(ctx, func() (interface{}, error) { return db.db.Query(sql, vars) })
+}
+
+func (db surrealDBContext) UpdateContext(ctx context.Context, what string, data interface{}) (interface{}, error) {
+	return withContext(ctx, func() (interface{}, error) { return db.db.Update(what, data) })
+}
+
+func (db surrealDBContext) CreateContext(ctx context.Context, thing string, data interface{}) (interface{}, error) {
+	return withContext(ctx, func() (interface{}, error) { return db.db.Create(thing, data) })
+}
+
+func withContext(ctx context.Context, call func() (interface{}, error)) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ctx.Done() == nil {
+		return call()
+	}
+	type result struct {
+		data interface{}
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		data, err := call()
+		done <- result{data, err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.data, r.err
+	}
+}
@@ -0,0 +1,109 @@
+package surrealhigh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBlockingDriver blocks its queries until release is closed
+type mockBlockingDriver struct {
+	mockDriverResult
+	release chan struct{}
+}
+
+func (driver mockBlockingDriver) Driver() SurrealDB { return driver }
+
+func (driver mockBlockingDriver) Query(sql string, vars interface{}) (interface{}, error) {
+	<-driver.release
+	return driver.mockDriverResult.Query(sql, vars)
+}
+
+// mockContextDriver is a context aware driver recording the context
+type mockContextDriver struct {
+	mockDriver
+	ctx *context.Context
+}
+
+func (driver mockContextDriver) DriverContext() SurrealDBContext { return driver }
+
+func (driver mockContextDriver) QueryContext(ctx context.Context, sql string, vars interface{}) (interface{}, error) {
+	*driver.ctx = ctx
+	return driver.Driver().Query(sql, vars)
+}
+
+func (driver mockContextDriver) UpdateContext(ctx context.Context, what string, data interface{}) (interface{}, error) {
+	*driver.ctx = ctx
+	return driver.Driver().Update(what, data)
+}
+
+func (driver mockContextDriver) CreateContext(ctx context.Context, thing string, data interface{}) (interface{}, error) {
+	*driver.ctx = ctx
+	return driver.Driver().Create(thing, data)
+}
+
+type mockCtxKey struct{}
+
+func TestDBSelect_DoContext(t *testing.T) {
+	t.Run("cancel blocking query", func(t *testing.T) {
+		db := mockBlockingDriver{mockDriverResult{update: new(bool)}, make(chan struct{})}
+		defer close(db.release)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := SelectOn[mockDoc](NewQueryFrom(Table("")), db).DoContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := SelectOn[mockDoc](NewQueryFrom(Table("")), newMockDriver()).DoContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("adapted driver", func(t *testing.T) {
+		docs, err := SelectOn[mockDoc](NewQueryFrom(Table("")), newMockDriver()).DoContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []mockDoc{{}}, docs)
+	})
+	t.Run("context aware driver", func(t *testing.T) {
+		db := mockContextDriver{newMockDriver(), new(context.Context)}
+		ctx := context.WithValue(context.Background(), mockCtxKey{}, 1)
+		_, err := SelectOn[mockDoc](NewQueryFrom(Table("")), db).DoContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ctx, *db.ctx)
+	})
+}
+
+// mockCreateDriver creates the things it is given
+type mockCreateDriver struct{ mockQueryDriver }
+
+func (driver mockCreateDriver) Driver() SurrealDB { return driver }
+
+func (driver mockCreateDriver) Create(thing string, data interface{}) (interface{}, error) {
+	return map[string]interface{}{"id": thing}, nil
+}
+
+func TestDefaultDoc_CreateContext(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		id, err := NewDefaultDoc(mockDocWithID{}, mockCreateDriver{}).CreateContext(context.Background())
+		require.NoError(t, err)
+		assert.NotEqual(t, Id(uuid.Nil), id)
+	})
+	t.Run("done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := NewDefaultDoc(mockDoc{}, newMockDriver()).CreateContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("context aware driver", func(t *testing.T) {
+		db := mockContextDriver{newMockDriver(), new(context.Context)}
+		ctx := context.WithValue(context.Background(), mockCtxKey{}, 1)
+		id, err := NewDefaultDoc(mockDocWithID{}, db).CreateContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Id(uuid.Nil), id)
+		assert.Equal(t, ctx, *db.ctx)
+	})
+}
@@ -0,0 +1,36 @@
+package surrealhigh
+
+import "strings"
+
+// Create is a CREATE statement of a doc with a new id
+type Create struct {
+	thing   Thing
+	content ConditionAtomVar
+}
+
+// NewCreate renders CREATE table:id CONTENT $v_..., the doc is bound with
+// NewConditionValue
+func NewCreate[D Doc](doc D) Create {
+	return Create{
+		thing:   NewID().Thing(doc.Table()),
+		content: NewConditionValue(doc),
+	}
+}
+
+// Thing is the record created
+func (c Create) Thing() Thing {
+	return c.thing
+}
+
+func (c Create) String() string {
+	b := strings.Builder{}
+	b.WriteString("CREATE ")
+	b.WriteString(c.thing.String())
+	b.WriteString(" CONTENT ")
+	b.WriteString(c.content.String())
+	return b.String()
+}
+
+func (c Create) valuedVars() []conditionAtomVar {
+	return c.content.valuedVars()
+}
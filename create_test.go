@@ -0,0 +1,18 @@
+package surrealhigh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreate(t *testing.T) {
+	c := NewCreate(mockEdge{At: 1})
+	assert.True(t, strings.HasPrefix(c.Thing().String(), "likes:"))
+	_, err := NewIDFromThing(c.Thing(), "likes")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE "+c.Thing().String()+" CONTENT $v_a3a0075239ad76f3", c.String())
+	assert.Equal(t, []conditionAtomVar{{name: varWhereClause("v_a3a0075239ad76f3"), value: mockEdge{At: 1}, encoded: []byte(`{"at":1}`)}}, c.valuedVars())
+}
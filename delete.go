@@ -0,0 +1,160 @@
+package surrealhigh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Return is the RETURN clause of the mutation statements
+type Return string
+
+const (
+	ReturnNone   = Return("NONE")
+	ReturnBefore = Return("BEFORE")
+	ReturnAfter  = Return("AFTER")
+	ReturnDiff   = Return("DIFF")
+)
+
+func NewDeleteFrom(from Table, opts ...DeleteOption) Delete {
+	d := Delete{
+		from: from,
+		what: from.String(),
+	}
+	for _, opt := range opts {
+		d = opt(d)
+	}
+	return d
+}
+
+type DeleteOption func(Delete) Delete
+
+func DeleteOptionWhere(c Condition) DeleteOption {
+	return func(d Delete) Delete {
+		d.where = c
+		return d
+	}
+}
+
+// DeleteOptionThing deletes the record th only, th is bound and must be a
+// record of the table, ErrNotInThisTable otherwise
+func DeleteOptionThing(th Thing) DeleteOption {
+	return func(d Delete) Delete {
+		d.thing, d.err = thingExpression(th), nil
+		if !strings.HasPrefix(th.String(), d.from.Prefix()) {
+			d.err = fmt.Errorf("%w: %q not in %q", ErrNotInThisTable, th, d.from)
+		}
+		return d
+	}
+}
+
+// DeleteOptionRange deletes the records of the table with ids in the range
+// from..to
+func DeleteOptionRange(from, to Id) DeleteOption {
+	return func(d Delete) Delete {
+		d.what = d.from.Prefix() + from.String() + ".." + to.String()
+		d.thing, d.err = nil, nil
+		return d
+	}
+}
+
+func DeleteOptionReturn(r Return) DeleteOption {
+	return func(d Delete) Delete {
+		d.ret = r
+		return d
+	}
+}
+
+// DeleteOptionTimeout stops the statement on the server after timeout (TIMEOUT)
+func DeleteOptionTimeout(timeout time.Duration) DeleteOption {
+	return func(d Delete) Delete {
+		d.timeout = timeout
+		return d
+	}
+}
+
+// DeleteOptionParallel processes records in parallel on the server (PARALLEL)
+func DeleteOptionParallel() DeleteOption {
+	return func(d Delete) Delete {
+		d.parallel = true
+		return d
+	}
+}
+
+// Delete is a DELETE statement
+type Delete struct {
+	from  Table
+	what  string
+	thing Expression
+	where valuedWhereClause
+	ret   Return
+	err   error
+
+	statementOptions
+}
+
+func (d Delete) String() string {
+	b := strings.Builder{}
+	b.WriteString("DELETE ")
+	if d.thing != nil {
+		b.WriteString(d.thing.String())
+	} else {
+		b.WriteString(d.what)
+	}
+	if d.where != nil {
+		b.WriteString(" WHERE ")
+		b.WriteString(d.where.String())
+	}
+	if d.ret != "" {
+		b.WriteString(" RETURN ")
+		b.WriteString(string(d.ret))
+	}
+	b.WriteString(d.statementOptions.String())
+	return b.String()
+}
+
+func (d Delete) valuedVars() []conditionAtomVar {
+	var vars []conditionAtomVar
+	if d.thing != nil {
+		vars = append(vars, d.thing.valuedVars()...)
+	}
+	if d.where != nil {
+		vars = append(vars, d.where.valuedVars()...)
+	}
+	return vars
+}
+
+func (d Delete) validate() error {
+	return d.err
+}
+
+type DBDelete[D any] interface {
+	// Do returns the deleted docs as returned by the RETURN clause, there is
+	// no doc by default and with RETURN NONE; it returns with the errors of
+	// DBSelect.All and ErrNotInThisTable, see DeleteOptionThing
+	Do() ([]D, error)
+}
+
+func DeleteOn[D any](d Delete, db SurrealDriver) DBDelete[D] {
+	return DBDelete[D](dbDelete[D]{
+		delete: d,
+		db:     db,
+	})
+}
+
+type dbDelete[D any] struct {
+	delete Delete
+	db     SurrealDriver
+}
+
+func (d dbDelete[D]) Do() ([]D, error) {
+	if err := d.delete.validate(); err != nil {
+		return nil, fmt.Errorf("delete: %w", err)
+	}
+	docs, err := queryAll[D](context.Background(), d.db, d.delete.String(), d.delete.valuedVars())
+	if err != nil {
+		return nil, fmt.Errorf("delete %q: %w", d.delete.what, err)
+	}
+	return docs, nil
+}
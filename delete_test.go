@@ -0,0 +1,117 @@
+package surrealhigh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeleteFrom(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		delete Delete
+		sql    string
+	}{
+		{
+			name:   "delete table",
+			delete: NewDeleteFrom(Table("logs")),
+			sql:    "DELETE logs",
+		},
+		{
+			name: "delete where return before",
+			delete: NewDeleteFrom(Table("logs"),
+				DeleteOptionWhere(NewConditionLessThan(Field("timestamp"), NewConditionAtomVar("ts", 0))),
+				DeleteOptionReturn(ReturnBefore)),
+			sql: "DELETE logs WHERE (timestamp < $ts) RETURN BEFORE",
+		},
+		{
+			name:   "delete thing return none",
+			delete: NewDeleteFrom(Table("logs"), DeleteOptionThing(Id(uuid.Nil).Thing("logs")), DeleteOptionReturn(ReturnNone)),
+			sql:    "DELETE type::thing($v_1b47ef78b3655f0c, $v_ee19a70264b21729) RETURN NONE",
+		},
+		{
+			name:   "delete range return diff",
+			delete: NewDeleteFrom(Table("logs"), DeleteOptionRange(Id(uuid.Nil), Id(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))), DeleteOptionReturn(ReturnDiff)),
+			sql:    "DELETE logs:00000000_0000_0000_0000_000000000000..ffffffff_ffff_ffff_ffff_ffffffffffff RETURN DIFF",
+		},
+		{
+			name:   "delete return none timeout parallel",
+			delete: NewDeleteFrom(Table("logs"), DeleteOptionReturn(ReturnNone), DeleteOptionTimeout(30*time.Second), DeleteOptionParallel()),
+			sql:    "DELETE logs RETURN NONE TIMEOUT 30s PARALLEL",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sql, test.delete.String())
+		})
+	}
+}
+
+func TestDBDelete_Do(t *testing.T) {
+	t.Run("mock driver result no error", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{
+			"result": []interface{}{map[string]interface{}{"RecordID": 1, "IsOut": true}},
+			"status": "OK",
+		}})
+		d := NewDeleteFrom(Table("records"),
+			DeleteOptionWhere(NewConditionIs(Field("is_out"), NewConditionAtomVar("out", true))),
+			DeleteOptionReturn(ReturnBefore))
+		docs, err := DeleteOn[mockDoc](d, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []mockDoc{{RecordID: 1, IsOut: true}}, docs)
+		assert.Equal(t, "DELETE records WHERE (is_out IS $out) RETURN BEFORE", *db.sql)
+		assert.Equal(t, map[string]interface{}{"out": true}, *db.vars)
+	})
+	t.Run("duplicate valuation", func(t *testing.T) {
+		d := NewDeleteFrom(Table("records"), DeleteOptionWhere(NewConditionAnd(
+			NewConditionIs(Field("a"), NewConditionAtomVar("v", 0)),
+			NewConditionIs(Field("b"), NewConditionAtomVar("v", 1)),
+		)))
+		_, err := DeleteOn[mockDoc](d, newMockQueryDriver(nil)).Do()
+		assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+	})
+	t.Run("return none", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		docs, err := DeleteOn[mockDoc](NewDeleteFrom(Table("records"), DeleteOptionReturn(ReturnNone)), db).Do()
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+	t.Run("no statement result", func(t *testing.T) {
+		_, err := DeleteOn[mockDoc](NewDeleteFrom(Table("records")), newMockQueryDriver([]interface{}{})).Do()
+		assert.ErrorIs(t, err, ErrNoResult)
+	})
+	t.Run("thing", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		_, err := DeleteOn[mockDoc](NewDeleteFrom(Table("logs"), DeleteOptionThing(Id(uuid.Nil).Thing("logs"))), db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"v_1b47ef78b3655f0c": "logs",
+			"v_ee19a70264b21729": "00000000_0000_0000_0000_000000000000",
+		}, *db.vars)
+	})
+	t.Run("thing injection", func(t *testing.T) {
+		d := NewDeleteFrom(Table("logs"), DeleteOptionThing(Thing("logs:a; DELETE person")))
+		assert.NotContains(t, d.String(), "person")
+		vars, err := valuate(d.valuedVars())
+		require.NoError(t, err)
+		var values []interface{}
+		for _, v := range vars {
+			values = append(values, v)
+		}
+		assert.ElementsMatch(t, []interface{}{"logs", "a; DELETE person"}, values)
+	})
+	t.Run("thing not in table", func(t *testing.T) {
+		db := newMockQueryDriver(nil)
+		d := NewDeleteFrom(Table("logs"), DeleteOptionThing(Thing("person:a")))
+		_, err := DeleteOn[mockDoc](d, db).Do()
+		assert.ErrorIs(t, err, ErrNotInThisTable)
+		assert.Empty(t, *db.sql)
+		b := NewBatch(db)
+		b.Add(d)
+		_, err = b.Run()
+		assert.ErrorIs(t, err, ErrNotInThisTable)
+		assert.Empty(t, *db.sql)
+	})
+}
@@ -1,6 +1,7 @@
 package surrealhigh
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -25,6 +26,7 @@ type DBDoc interface {
 	Doc
 	json.Marshaler
 	Create() (Id, error)
+	CreateContext(ctx context.Context) (Id, error)
 
 	db() SurrealDB
 }
@@ -32,13 +34,13 @@ type DBDoc interface {
 func NewDefaultDoc(doc Doc, db SurrealDriver) DefaultDoc {
 	return DefaultDoc{
 		doc:    doc,
-		driver: db.Driver(),
+		driver: db,
 	}
 }
 
 type DefaultDoc struct {
 	doc    Doc
-	driver SurrealDB
+	driver SurrealDriver
 }
 
 var _ DBDoc = DefaultDoc{}
@@ -52,19 +54,25 @@ func (doc DefaultDoc) MarshalJSON() ([]byte, error) {
 }
 
 func (doc DefaultDoc) db() SurrealDB {
-	return doc.driver
+	return doc.driver.Driver()
 }
 
 var nilID = Id(uuid.Nil)
 
 func (doc DefaultDoc) Create() (Id, error) {
+	return doc.CreateContext(context.Background())
+}
+
+// CreateContext is Create with a context, it also returns with the error of
+// ctx when ctx is done before the doc is created
+func (doc DefaultDoc) CreateContext(ctx context.Context) (Id, error) {
 
 	// TODO(malikbenkirane) rm
 	errWrapf := func(f string, a ...interface{}) (Id, error) {
 		return nilID, fmt.Errorf(f, a...)
 	}
 
-	data, err := doc.db().Create(string(NewID().Thing(doc.Table())), doc.doc)
+	data, err := driverContext(doc.driver).CreateContext(ctx, string(NewID().Thing(doc.Table())), doc.doc)
 	if err != nil {
 		return errWrapf("sdb: create: %w", err)
 	}
@@ -0,0 +1,82 @@
+package surrealhigh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Classes of ErrStatement, use errors.Is to classify a statement error
+var (
+	// ErrConflict is a write conflict, the operation can be retried
+	ErrConflict      = errors.New("conflict")
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrPermission    = errors.New("permission denied")
+	ErrParse         = errors.New("parse error")
+	// ErrNotExecuted is a statement not executed because its transaction
+	// failed or was cancelled
+	ErrNotExecuted = errors.New("not executed")
+)
+
+// ErrStatement is the error reported by SurrealDB for a statement which
+// status is not OK
+type ErrStatement struct {
+	Status    string
+	Detail    string
+	Time      time.Duration
+	Statement string
+}
+
+func (err ErrStatement) Error() string {
+	return fmt.Sprintf("statement %q: status %s: %s", err.Statement, err.Status, err.Detail)
+}
+
+// Is classifies the error with ErrConflict, ErrNotFound, ErrAlreadyExists,
+// ErrPermission, ErrParse or ErrNotExecuted
+func (err ErrStatement) Is(target error) bool {
+	detail := strings.ToLower(err.Detail)
+	containsAny := func(s ...string) bool {
+		for _, s := range s {
+			if strings.Contains(detail, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch target {
+	case ErrConflict:
+		return containsAny("conflict", "can be retried")
+	case ErrNotFound:
+		return containsAny("not found", "does not exist")
+	case ErrAlreadyExists:
+		return containsAny("already exists")
+	case ErrPermission:
+		return containsAny("permission", "not allowed", "iam error")
+	case ErrParse:
+		return containsAny("parse error", "failed to parse")
+	case ErrNotExecuted:
+		return containsAny("not executed")
+	}
+	return false
+}
+
+const statementStatusOK = "OK"
+
+// err is the ErrStatement of the result of statement if any
+func (r statementResult) err(statement string) error {
+	if r.Status == statementStatusOK {
+		return nil
+	}
+	detail := r.Detail
+	if msg, ok := r.Result.(string); ok && detail == "" {
+		detail = msg
+	}
+	return ErrStatement{
+		Status:    r.Status,
+		Detail:    detail,
+		Time:      r.duration(),
+		Statement: statement,
+	}
+}
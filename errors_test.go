@@ -0,0 +1,57 @@
+package surrealhigh
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrStatement_Is(t *testing.T) {
+	classes := []error{ErrConflict, ErrNotFound, ErrAlreadyExists, ErrPermission, ErrParse, ErrNotExecuted}
+	for _, test := range []struct {
+		detail string
+		class  error
+	}{
+		{"Failed to commit transaction due to a read or write conflict. This transaction can be retried", ErrConflict},
+		{"The table 'person' does not exist", ErrNotFound},
+		{"Database record `person:a` already exists", ErrAlreadyExists},
+		{"Not enough permissions to perform this action", ErrPermission},
+		{"Parse error on line 1 at character 0 when parsing 'SELEC'", ErrParse},
+		{"The query was not executed due to a failed transaction", ErrNotExecuted},
+		{"The query was not executed due to a cancelled transaction", ErrNotExecuted},
+		{"some other error", nil},
+	} {
+		t.Run(test.detail, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", ErrStatement{Status: "ERR", Detail: test.detail})
+			for _, class := range classes {
+				assert.Equal(t, class == test.class, errors.Is(err, class), class.Error())
+			}
+			var errStatement ErrStatement
+			require.ErrorAs(t, err, &errStatement)
+			assert.Equal(t, test.detail, errStatement.Detail)
+		})
+	}
+}
+
+func TestDBSelect_Do_statusErr(t *testing.T) {
+	db := newMockQueryDriver([]interface{}{map[string]interface{}{
+		"result": "The table 'records' does not exist",
+		"status": "ERR",
+		"time":   "10µs",
+	}})
+	_, err := SelectOn[mockDoc](NewQueryFrom(Table("records")), db).Do()
+	var errStatement ErrStatement
+	require.ErrorAs(t, err, &errStatement)
+	assert.Equal(t, ErrStatement{
+		Status:    "ERR",
+		Detail:    "The table 'records' does not exist",
+		Time:      10 * time.Microsecond,
+		Statement: "SELECT * FROM records",
+	}, errStatement)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrNoResult)
+}
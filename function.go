@@ -0,0 +1,145 @@
+package surrealhigh
+
+import (
+	"strings"
+	"time"
+)
+
+// Expression is a call to a SurrealQL function, it is both a ConditionAtom and
+// a Projection, e.g. a case insensitive match
+//
+//	NewConditionEqual(NewFunctionStringLowercase(Field("name")), NewConditionValue("bob"))
+type Expression interface{ valuedWhereClause }
+
+var _ Expression = valuedFunctionWhereClause{}
+
+// NewFunction renders the call name(args, ...) of any SurrealQL function
+func NewFunction(name string, args ...ConditionAtom) Expression {
+	c := valuedFunctionWhereClause{name: name}
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+	}
+	return c
+}
+
+// NewFunctionStringLowercase renders string::lowercase(s)
+func NewFunctionStringLowercase(s ConditionAtom) Expression {
+	return NewFunction("string::lowercase", s)
+}
+
+// NewFunctionStringUppercase renders string::uppercase(s)
+func NewFunctionStringUppercase(s ConditionAtom) Expression {
+	return NewFunction("string::uppercase", s)
+}
+
+// NewFunctionStringTrim renders string::trim(s)
+func NewFunctionStringTrim(s ConditionAtom) Expression {
+	return NewFunction("string::trim", s)
+}
+
+// NewFunctionStringLength renders string::length(s)
+func NewFunctionStringLength(s ConditionAtom) Expression {
+	return NewFunction("string::length", s)
+}
+
+// NewFunctionStringConcat renders string::concat(s, ...)
+func NewFunctionStringConcat(s ...ConditionAtom) Expression {
+	return NewFunction("string::concat", s...)
+}
+
+// NewFunctionStringStartsWith renders string::startsWith(s, prefix)
+func NewFunctionStringStartsWith(s, prefix ConditionAtom) Expression {
+	return NewFunction("string::startsWith", s, prefix)
+}
+
+// NewFunctionStringEndsWith renders string::endsWith(s, suffix)
+func NewFunctionStringEndsWith(s, suffix ConditionAtom) Expression {
+	return NewFunction("string::endsWith", s, suffix)
+}
+
+// NewFunctionTimeNow renders time::now()
+func NewFunctionTimeNow() Expression {
+	return NewFunction("time::now")
+}
+
+// NewFunctionTimeFloor renders time::floor(t, d) with d a duration literal,
+// e.g. time::floor(created, 1h)
+func NewFunctionTimeFloor(t ConditionAtom, d time.Duration) Expression {
+	return NewFunction("time::floor", t, durationWhereClause(d))
+}
+
+// NewFunctionTimeRound renders time::round(t, d) with d a duration literal
+func NewFunctionTimeRound(t ConditionAtom, d time.Duration) Expression {
+	return NewFunction("time::round", t, durationWhereClause(d))
+}
+
+// NewFunctionArrayLen renders array::len(a)
+func NewFunctionArrayLen(a ConditionAtom) Expression {
+	return NewFunction("array::len", a)
+}
+
+// NewFunctionArrayDistinct renders array::distinct(a)
+func NewFunctionArrayDistinct(a ConditionAtom) Expression {
+	return NewFunction("array::distinct", a)
+}
+
+// NewFunctionGeoDistance renders geo::distance(a, b), the distance in meters
+// between two points
+func NewFunctionGeoDistance(a, b ConditionAtom) Expression {
+	return NewFunction("geo::distance", a, b)
+}
+
+// NewFunctionCryptoMd5 renders crypto::md5(s)
+func NewFunctionCryptoMd5(s ConditionAtom) Expression {
+	return NewFunction("crypto::md5", s)
+}
+
+// NewFunctionCryptoSha256 renders crypto::sha256(s)
+func NewFunctionCryptoSha256(s ConditionAtom) Expression {
+	return NewFunction("crypto::sha256", s)
+}
+
+// NewFunctionCryptoSha512 renders crypto::sha512(s)
+func NewFunctionCryptoSha512(s ConditionAtom) Expression {
+	return NewFunction("crypto::sha512", s)
+}
+
+// NewFunctionCryptoArgon2Generate renders crypto::argon2::generate(pass)
+func NewFunctionCryptoArgon2Generate(pass ConditionAtom) Expression {
+	return NewFunction("crypto::argon2::generate", pass)
+}
+
+// NewFunctionCryptoArgon2Compare renders crypto::argon2::compare(hash, pass)
+func NewFunctionCryptoArgon2Compare(hash, pass ConditionAtom) Expression {
+	return NewFunction("crypto::argon2::compare", hash, pass)
+}
+
+// NewFunctionTypeThing renders type::thing(table, id), the record of table
+// with id
+func NewFunctionTypeThing(table, id ConditionAtom) Expression {
+	return NewFunction("type::thing", table, id)
+}
+
+// thingExpression is th as type::thing($tb, $id) with its table and id bound,
+// a Thing from the caller is never written into a statement
+func thingExpression(th Thing) Expression {
+	tb, id, _ := strings.Cut(th.String(), ":")
+	return NewFunctionTypeThing(NewConditionValue(tb), NewConditionValue(id))
+}
+
+// durationWhereClause is a duration literal such as 1h30m
+type durationWhereClause time.Duration
+
+var _ valuedWhereClause = durationWhereClause(0)
+
+func (c durationWhereClause) String() string {
+	return durationString(time.Duration(c))
+}
+
+func (c durationWhereClause) asWhereClause() whereClause {
+	return c
+}
+
+func (c durationWhereClause) valuedVars() []conditionAtomVar {
+	return []conditionAtomVar{}
+}
@@ -0,0 +1,75 @@
+package surrealhigh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFunction(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		e    Expression
+		sql  string
+	}{
+		{
+			name: "lowercase",
+			e:    NewFunctionStringLowercase(Field("name")),
+			sql:  "string::lowercase(name)",
+		},
+		{
+			name: "concat",
+			e:    NewFunctionStringConcat(Field("first"), NewConditionAtomVar("sep", " "), Field("last")),
+			sql:  "string::concat(first, $sep, last)",
+		},
+		{
+			name: "now",
+			e:    NewFunctionTimeNow(),
+			sql:  "time::now()",
+		},
+		{
+			name: "floor",
+			e:    NewFunctionTimeFloor(Field("created"), 24*time.Hour),
+			sql:  "time::floor(created, 24h)",
+		},
+		{
+			name: "nested",
+			e:    NewFunctionArrayLen(NewFunctionArrayDistinct(Field("tags"))),
+			sql:  "array::len(array::distinct(tags))",
+		},
+		{
+			name: "argon2",
+			e:    NewFunctionCryptoArgon2Compare(Field("pass"), NewConditionAtomVar("pass", "secret")),
+			sql:  "crypto::argon2::compare(pass, $pass)",
+		},
+		{
+			name: "thing",
+			e:    NewFunctionTypeThing(NewConditionAtomVar("tb", "person"), NewConditionAtomVar("id", "a")),
+			sql:  "type::thing($tb, $id)",
+		},
+		{
+			name: "any function",
+			e:    NewFunction("rand::uuid::v4"),
+			sql:  "rand::uuid::v4()",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sql, test.e.String())
+		})
+	}
+}
+
+func TestNewFunction_select(t *testing.T) {
+	q := NewQueryFrom(Table("person"),
+		QueryOptionFields(Field("name"), NewProjectionAs(NewFunctionGeoDistance(Field("home"), NewConditionAtomVar("here", nil)), Field("distance"))),
+		QueryOptionWhere(NewConditionAnd(
+			NewConditionEqual(NewFunctionStringLowercase(Field("name")), NewConditionAtomVar("name", "bob")),
+			NewConditionLessThan(Field("seen"), NewFunctionTimeNow()))))
+	assert.Equal(t, "SELECT name, geo::distance(home, $here) AS distance FROM person "+
+		"WHERE ((string::lowercase(name) = $name) AND (seen < time::now()))", q.String())
+	vars, err := valuate(q.valuedVars())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"here": nil, "name": "bob"}, vars)
+}
@@ -0,0 +1,130 @@
+package surrealhigh
+
+import "strings"
+
+// GraphPath is a graph traversal expression such as ->likes->post or
+// <-wrote<-person; it is both a Projection and a ConditionAtom
+//
+//	NewGraphOut("likes").Out("post").Where(c) // ->likes->(post WHERE c)
+type GraphPath struct {
+	hops []valuedGraphHop
+}
+
+var (
+	_ Projection    = GraphPath{}
+	_ ConditionAtom = GraphPath{}
+)
+
+type graphDirection string
+
+const (
+	graphDirectionOut  = graphDirection("->")
+	graphDirectionIn   = graphDirection("<-")
+	graphDirectionBoth = graphDirection("<->")
+)
+
+type valuedGraphHop struct {
+	dir   graphDirection
+	table Table
+	where valuedWhereClause
+}
+
+// NewGraphOut starts a path following outgoing edges ->t
+func NewGraphOut(t Table) GraphPath {
+	return GraphPath{}.hop(graphDirectionOut, t)
+}
+
+// NewGraphIn starts a path following incoming edges <-t
+func NewGraphIn(t Table) GraphPath {
+	return GraphPath{}.hop(graphDirectionIn, t)
+}
+
+// NewGraphBoth starts a path following edges in both directions <->t
+func NewGraphBoth(t Table) GraphPath {
+	return GraphPath{}.hop(graphDirectionBoth, t)
+}
+
+// Out appends the hop ->t
+func (p GraphPath) Out(t Table) GraphPath {
+	return p.hop(graphDirectionOut, t)
+}
+
+// In appends the hop <-t
+func (p GraphPath) In(t Table) GraphPath {
+	return p.hop(graphDirectionIn, t)
+}
+
+// Both appends the hop <->t
+func (p GraphPath) Both(t Table) GraphPath {
+	return p.hop(graphDirectionBoth, t)
+}
+
+// Where filters the last hop of the path, ->(t WHERE c); a path without hop
+// is returned unchanged
+func (p GraphPath) Where(c Condition) GraphPath {
+	if len(p.hops) == 0 {
+		return p
+	}
+	hops := append([]valuedGraphHop{}, p.hops...)
+	hops[len(hops)-1].where = c
+	return GraphPath{hops: hops}
+}
+
+func (p GraphPath) hop(dir graphDirection, t Table) GraphPath {
+	hops := append([]valuedGraphHop{}, p.hops...)
+	return GraphPath{hops: append(hops, valuedGraphHop{dir: dir, table: t})}
+}
+
+func (p GraphPath) asWhereClause() whereClause {
+	var c graphWhereClause
+	for _, hop := range p.hops {
+		h := graphHop{dir: hop.dir, table: hop.table}
+		if hop.where != nil {
+			h.where = hop.where.asWhereClause()
+		}
+		c.hops = append(c.hops, h)
+	}
+	return c
+}
+
+func (p GraphPath) String() string {
+	return p.asWhereClause().String()
+}
+
+func (p GraphPath) valuedVars() (vars []conditionAtomVar) {
+	for _, hop := range p.hops {
+		if hop.where != nil {
+			vars = append(vars, hop.where.valuedVars()...)
+		}
+	}
+	return vars
+}
+
+type graphHop struct {
+	dir   graphDirection
+	table Table
+	where whereClause
+}
+
+type graphWhereClause struct {
+	hops []graphHop
+}
+
+var _ whereClause = graphWhereClause{}
+
+func (c graphWhereClause) String() string {
+	b := strings.Builder{}
+	for _, hop := range c.hops {
+		b.WriteString(string(hop.dir))
+		if hop.where == nil {
+			b.WriteString(hop.table.String())
+			continue
+		}
+		b.WriteString("(")
+		b.WriteString(hop.table.String())
+		b.WriteString(" WHERE ")
+		b.WriteString(hop.where.String())
+		b.WriteString(")")
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package surrealhigh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphPath_String(t *testing.T) {
+	for _, test := range []struct {
+		path GraphPath
+		sql  string
+	}{
+		{NewGraphOut("likes").Out("post"), "->likes->post"},
+		{NewGraphIn("wrote").In("person"), "<-wrote<-person"},
+		{NewGraphBoth("knows").Both("person"), "<->knows<->person"},
+		{
+			NewGraphOut("likes").Out("post").Where(
+				NewConditionEqual(Field("published"), NewConditionAtomVar("published", true))),
+			"->likes->(post WHERE (published = $published))",
+		},
+		{
+			NewGraphIn("wrote").Where(
+				NewConditionGreaterThan(Field("at"), NewConditionAtomVar("at", 0))).In("person"),
+			"<-(wrote WHERE (at > $at))<-person",
+		},
+	} {
+		t.Run(test.sql, func(t *testing.T) {
+			assert.Equal(t, test.sql, test.path.String())
+		})
+	}
+}
+
+func TestGraphPath_Select(t *testing.T) {
+	liked := NewGraphOut("likes").Out("post").Where(
+		NewConditionEqual(Field("published"), NewConditionAtomVar("published", true)))
+	authors := NewGraphIn("wrote").In("person")
+	q := NewQueryFrom(Table("person"),
+		QueryOptionFields(Field("id"), NewProjectionAs(liked, Field("liked"))),
+		QueryOptionWhere(NewConditionContains(authors, NewConditionAtomVar("author", "person:a"))),
+	)
+	assert.Equal(t, "SELECT id, ->likes->(post WHERE (published = $published)) AS liked FROM person WHERE (<-wrote<-person CONTAINS $author)", q.String())
+	assert.Equal(t, []conditionAtomVar{
+		{name: varWhereClause("published"), value: true},
+		{name: varWhereClause("author"), value: "person:a"},
+	}, q.valuedVars())
+}
+
+func TestGraphPath_immutable(t *testing.T) {
+	p := NewGraphOut("likes")
+	_ = p.Out("post")
+	_ = p.Where(NewConditionEqual(Field("a"), NewConditionAtomVar("a", 0)))
+	assert.Equal(t, "->likes", p.String())
+}
+
+func TestGraphPath_Where_noHop(t *testing.T) {
+	p := GraphPath{}.Where(NewConditionEqual(Field("published"), NewConditionAtomVar("published", true)))
+	assert.Equal(t, "", p.String())
+	assert.Empty(t, p.valuedVars())
+}
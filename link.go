@@ -0,0 +1,55 @@
+package surrealhigh
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Link is a record link field which holds the Thing it points to and, when
+// the field is fetched (see QueryOptionFetch), the linked doc D
+type Link[D any] struct {
+	Thing Thing
+	Doc   *D
+}
+
+func NewLink[D any](th Thing) Link[D] {
+	return Link[D]{Thing: th}
+}
+
+// Fetched reports whether the linked doc was inlined
+func (l Link[D]) Fetched() bool {
+	return l.Doc != nil
+}
+
+// MarshalJSON always marshals the link as its Thing so that the linked doc is
+// never written back in place of the link, a zero link is null
+func (l Link[D]) MarshalJSON() ([]byte, error) {
+	if l.Thing == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(l.Thing.String())
+}
+
+func (l *Link[D]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*l = Link[D]{}
+		return nil
+	}
+	var th string
+	if err := json.Unmarshal(b, &th); err == nil {
+		*l = Link[D]{Thing: Thing(th)}
+		return nil
+	}
+	var doc D
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("link: unmarshal doc: %w", err)
+	}
+	var docId struct {
+		RawId string `json:"id"`
+	}
+	if err := json.Unmarshal(b, &docId); err != nil {
+		return fmt.Errorf("link: unmarshal doc id: %w", err)
+	}
+	*l = Link[D]{Thing: Thing(docId.RawId), Doc: &doc}
+	return nil
+}
@@ -0,0 +1,66 @@
+package surrealhigh
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAuthor struct {
+	Name string `json:"name"`
+}
+
+type mockPost struct {
+	Title  string           `json:"title"`
+	Author Link[mockAuthor] `json:"author"`
+}
+
+func TestLink_UnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+		post mockPost
+	}{
+		{
+			name: "thing",
+			data: `{"title":"t","author":"person:a"}`,
+			post: mockPost{Title: "t", Author: Link[mockAuthor]{Thing: "person:a"}},
+		},
+		{
+			name: "fetched",
+			data: `{"title":"t","author":{"id":"person:a","name":"n"}}`,
+			post: mockPost{Title: "t", Author: Link[mockAuthor]{Thing: "person:a", Doc: &mockAuthor{Name: "n"}}},
+		},
+		{
+			name: "null",
+			data: `{"title":"t","author":null}`,
+			post: mockPost{Title: "t"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var post mockPost
+			require.NoError(t, json.Unmarshal([]byte(test.data), &post))
+			assert.Equal(t, test.post, post)
+			assert.Equal(t, test.post.Author.Doc != nil, post.Author.Fetched())
+		})
+	}
+	t.Run("bad doc", func(t *testing.T) {
+		var post mockPost
+		assert.Error(t, json.Unmarshal([]byte(`{"author":{"name":0}}`), &post))
+	})
+}
+
+func TestLink_MarshalJSON(t *testing.T) {
+	post := mockPost{Title: "t", Author: Link[mockAuthor]{Thing: "person:a", Doc: &mockAuthor{Name: "n"}}}
+	b, err := json.Marshal(post)
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"t","author":"person:a"}`, string(b))
+	assert.Equal(t, NewLink[mockAuthor]("person:a"), Link[mockAuthor]{Thing: "person:a"})
+	t.Run("zero link", func(t *testing.T) {
+		b, err := json.Marshal(mockPost{Title: "t"})
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"t","author":null}`, string(b))
+	})
+}
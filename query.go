@@ -1,8 +1,12 @@
 package surrealhigh
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -28,28 +32,148 @@ func QueryOptionWhere(c Condition) QueryOption {
 	}
 }
 
-func QueryOptionOrderByAsc(f Field) QueryOption {
+func QueryOptionOrderByAsc(f Field, opts ...OrderOption) QueryOption {
+	return queryOptionOrderBy(f, selectOrderAsc, opts...)
+}
+
+func QueryOptionOrderByDesc(f Field, opts ...OrderOption) QueryOption {
+	return queryOptionOrderBy(f, selectOrderDesc, opts...)
+}
+
+// QueryOptionOrderByRand orders results randomly (ORDER BY RAND())
+func QueryOptionOrderByRand() QueryOption {
+	return func(q Select) Select {
+		q.orderBy = append(append([]selectOrderBy{}, q.orderBy...), selectOrderBy{rand: true})
+		return q
+	}
+}
+
+func queryOptionOrderBy(f Field, order selectOrder, opts ...OrderOption) QueryOption {
 	return func(q Select) Select {
-		q.orderBy = &selectOrderBy{
+		o := selectOrderBy{
 			field: f,
-			order: selectOrderAsc,
+			order: order,
+		}
+		for _, opt := range opts {
+			o = opt(o)
 		}
+		q.orderBy = append(append([]selectOrderBy{}, q.orderBy...), o)
 		return q
 	}
 }
 
-func QueryOptionOrderByDesc(f Field) QueryOption {
+type OrderOption func(selectOrderBy) selectOrderBy
+
+// OrderOptionCollate orders strings with unicode collation (COLLATE)
+func OrderOptionCollate() OrderOption {
+	return func(o selectOrderBy) selectOrderBy {
+		o.collate = true
+		return o
+	}
+}
+
+// OrderOptionNumeric orders strings containing numbers numerically (NUMERIC)
+func OrderOptionNumeric() OrderOption {
+	return func(o selectOrderBy) selectOrderBy {
+		o.numeric = true
+		return o
+	}
+}
+
+// QueryOptionFields selects the given projections instead of *
+func QueryOptionFields(p ...Projection) QueryOption {
 	return func(q Select) Select {
-		q.orderBy = &selectOrderBy{
-			field: f,
-			order: selectOrderDesc,
+		q.fields = append([]valuedWhereClause{}, q.fields...)
+		for _, p := range p {
+			q.fields = append(q.fields, p)
 		}
+		q.value = false
+		return q
+	}
+}
+
+// QueryOptionValue selects the value of the projection only (SELECT VALUE p)
+func QueryOptionValue(p Projection) QueryOption {
+	return func(q Select) Select {
+		q.fields = []valuedWhereClause{p}
+		q.value = true
+		return q
+	}
+}
+
+// QueryOptionLimit limits the number of results to n
+func QueryOptionLimit(n int) QueryOption {
+	return func(q Select) Select {
+		q.limit = n
+		return q
+	}
+}
+
+// QueryOptionStart skips the first n results
+func QueryOptionStart(n int) QueryOption {
+	return func(q Select) Select {
+		q.start = n
+		return q
+	}
+}
+
+// QueryOptionGroupBy groups results by the given fields (GROUP BY f, ...)
+func QueryOptionGroupBy(f ...Field) QueryOption {
+	return func(q Select) Select {
+		q.groupBy = append(append([]Field{}, q.groupBy...), f...)
+		q.groupAll = false
+		return q
+	}
+}
+
+// QueryOptionGroupAll groups all results in a single row (GROUP ALL)
+func QueryOptionGroupAll() QueryOption {
+	return func(q Select) Select {
+		q.groupBy = nil
+		q.groupAll = true
 		return q
 	}
 }
 
+// QueryOptionFetch inlines the records linked by the given fields (FETCH f, ...),
+// see Link for decoding such fields
+func QueryOptionFetch(f ...Field) QueryOption {
+	return func(q Select) Select {
+		q.fetch = append(append([]Field{}, q.fetch...), f...)
+		return q
+	}
+}
+
+// QueryOptionTimeout stops the statement on the server after timeout (TIMEOUT)
+func QueryOptionTimeout(timeout time.Duration) QueryOption {
+	return func(q Select) Select {
+		q.timeout = timeout
+		return q
+	}
+}
+
+// QueryOptionParallel fetches records in parallel on the server (PARALLEL)
+func QueryOptionParallel() QueryOption {
+	return func(q Select) Select {
+		q.parallel = true
+		return q
+	}
+}
+
+// Select is a SELECT statement, it is also a ConditionAtom and a Projection
+// rendered as a subquery (SELECT ...) which variables are those of the outer
+// statement
 type Select struct{ valuedSelectStatement }
 
+var (
+	_ ConditionAtom = Select{}
+	_ Projection    = Select{}
+)
+
+func (q Select) asWhereClause() whereClause {
+	return subqueryWhereClause{q.valuedSelectStatement.asWhereClause()}
+}
+
 var _ valuedWhereClause = valuedSelectStatement{}
 
 func (vc valuedSelectStatement) String() string {
@@ -58,8 +182,18 @@ func (vc valuedSelectStatement) String() string {
 
 func (vc valuedSelectStatement) asWhereClause() whereClause {
 	c := selectStatement{
-		orderBy: vc.orderBy,
-		from:    vc.from,
+		orderBy:          vc.orderBy,
+		from:             vc.from,
+		value:            vc.value,
+		groupBy:          vc.groupBy,
+		groupAll:         vc.groupAll,
+		limit:            vc.limit,
+		start:            vc.start,
+		fetch:            vc.fetch,
+		statementOptions: vc.statementOptions,
+	}
+	for _, f := range vc.fields {
+		c.fields = append(c.fields, f.asWhereClause())
 	}
 	if vc.where != nil {
 		c.where = vc.where.asWhereClause()
@@ -67,20 +201,44 @@ func (vc valuedSelectStatement) asWhereClause() whereClause {
 	return c
 }
 
-func (vc valuedSelectStatement) valuedVars() []conditionAtomVar {
-	return vc.where.valuedVars()
+func (vc valuedSelectStatement) valuedVars() (vars []conditionAtomVar) {
+	for _, f := range vc.fields {
+		vars = append(vars, f.valuedVars()...)
+	}
+	if vc.where != nil {
+		vars = append(vars, vc.where.valuedVars()...)
+	}
+	return vars
 }
 
 type valuedSelectStatement struct {
-	orderBy *selectOrderBy
-	where   valuedWhereClause
-	from    Table
+	fields   []valuedWhereClause
+	value    bool
+	groupBy  []Field
+	groupAll bool
+	orderBy  []selectOrderBy
+	limit    int
+	start    int
+	fetch    []Field
+	where    valuedWhereClause
+	from     Table
+
+	statementOptions
 }
 
 type selectStatement struct {
-	orderBy *selectOrderBy
-	from    Table
-	where   whereClause
+	fields   []whereClause
+	value    bool
+	groupBy  []Field
+	groupAll bool
+	orderBy  []selectOrderBy
+	limit    int
+	start    int
+	fetch    []Field
+	where    whereClause
+	from     Table
+
+	statementOptions
 }
 
 type (
@@ -92,12 +250,19 @@ type (
 
 	ConditionAtomField interface{ ConditionAtom }
 	ConditionAtomVar   interface{ ConditionAtom }
+
+	// Projection is an expression selected by a Select, a Field is a Projection
+	Projection interface{ valuedWhereClause }
 )
 
 var (
+	_ Projection         = Field("")
+	_ Projection         = valuedAliasWhereClause{}
+	_ Projection         = valuedFunctionWhereClause{}
 	_ ConditionAtomField = fieldWhereClause("")
 	_ ConditionAtomVar   = conditionAtomVar{}
 	_ Condition          = valuedBinaryWhereClause{}
+	_ Condition          = valuedUnaryWhereClause{}
 )
 
 type valuedWhereClause interface {
@@ -113,10 +278,29 @@ type valuedBinaryWhereClause struct {
 	op whereOp
 }
 
+type valuedFunctionWhereClause struct {
+	name string
+	args []valuedWhereClause
+}
+
+type valuedAliasWhereClause struct {
+	p     valuedWhereClause
+	alias Field
+}
+
+type valuedUnaryWhereClause struct {
+	c  valuedWhereClause
+	op whereOp
+}
+
 var (
 	_ valuedWhereClause = fieldWhereClause("")
 	_ valuedWhereClause = valuedBinaryWhereClause{}
+	_ valuedWhereClause = valuedUnaryWhereClause{}
+	_ valuedWhereClause = valuedAliasWhereClause{}
+	_ valuedWhereClause = valuedFunctionWhereClause{}
 	_ valuedWhereClause = conditionAtomVar{}
+	_ valuedWhereClause = Field("")
 )
 
 func (vc valuedBinaryWhereClause) asWhereClause() whereClause {
@@ -131,6 +315,44 @@ func (vc valuedBinaryWhereClause) String() string {
 	return vc.asWhereClause().String()
 }
 
+func (vc valuedUnaryWhereClause) asWhereClause() whereClause {
+	return unaryWhereClause{
+		c:  vc.c.asWhereClause(),
+		op: vc.op,
+	}
+}
+
+func (vc valuedUnaryWhereClause) String() string {
+	return vc.asWhereClause().String()
+}
+
+func (vc valuedFunctionWhereClause) asWhereClause() whereClause {
+	c := functionWhereClause{name: vc.name}
+	for _, arg := range vc.args {
+		c.args = append(c.args, arg.asWhereClause())
+	}
+	return c
+}
+
+func (vc valuedFunctionWhereClause) String() string {
+	return vc.asWhereClause().String()
+}
+
+func (vc valuedAliasWhereClause) asWhereClause() whereClause {
+	return aliasWhereClause{
+		p:     vc.p.asWhereClause(),
+		alias: vc.alias,
+	}
+}
+
+func (vc valuedAliasWhereClause) String() string {
+	return vc.asWhereClause().String()
+}
+
+func (f Field) asWhereClause() whereClause {
+	return fieldWhereClause(f)
+}
+
 func (vc fieldWhereClause) asWhereClause() whereClause {
 	return vc
 }
@@ -145,6 +367,25 @@ func (c valuedBinaryWhereClause) valuedVars() (vars []conditionAtomVar) {
 	return vars
 }
 
+func (c valuedUnaryWhereClause) valuedVars() []conditionAtomVar {
+	return c.c.valuedVars()
+}
+
+func (c valuedFunctionWhereClause) valuedVars() (vars []conditionAtomVar) {
+	for _, arg := range c.args {
+		vars = append(vars, arg.valuedVars()...)
+	}
+	return vars
+}
+
+func (c valuedAliasWhereClause) valuedVars() []conditionAtomVar {
+	return c.p.valuedVars()
+}
+
+func (f Field) valuedVars() []conditionAtomVar {
+	return []conditionAtomVar{}
+}
+
 func (c fieldWhereClause) valuedVars() []conditionAtomVar {
 	return []conditionAtomVar{}
 }
@@ -167,12 +408,62 @@ func NewConditionAtomVar(name string, value interface{}) ConditionAtomVar {
 type conditionAtomVar struct {
 	name  varWhereClause
 	value interface{}
+
+	// encoded is the encoding of value the name of the vars of
+	// NewConditionValue is generated from, it is nil for the other vars
+	encoded []byte
 }
 
 func (v conditionAtomVar) String() string {
 	return v.name.String()
 }
 
+// NewConditionValue is a ConditionAtomVar which name is generated from the
+// JSON encoding of value when rendered, e.g. $v_4e6bc1d9a2f0c3b7; equal values
+// share a variable so that conditions built apart can be composed. Different
+// values sharing a name fail with ErrDuplicateValuation. Names starting with
+// v_ are reserved to it.
+func NewConditionValue(value interface{}) ConditionAtomVar {
+	return conditionValue{value}
+}
+
+type conditionValue struct {
+	value interface{}
+}
+
+var _ valuedWhereClause = conditionValue{}
+
+func (c conditionValue) encoded() []byte {
+	b, err := json.Marshal(c.value)
+	if err != nil {
+		b = []byte(fmt.Sprintf("%T %#v", c.value, c.value))
+	}
+	return b
+}
+
+func (c conditionValue) name() varWhereClause {
+	return conditionValueName(c.encoded())
+}
+
+func conditionValueName(encoded []byte) varWhereClause {
+	h := fnv.New64a()
+	h.Write(encoded)
+	return varWhereClause(fmt.Sprintf("v_%016x", h.Sum64()))
+}
+
+func (c conditionValue) String() string {
+	return c.name().String()
+}
+
+func (c conditionValue) asWhereClause() whereClause {
+	return c.name()
+}
+
+func (c conditionValue) valuedVars() []conditionAtomVar {
+	encoded := c.encoded()
+	return []conditionAtomVar{{name: conditionValueName(encoded), value: c.value, encoded: encoded}}
+}
+
 func NewConditionIs(l ConditionAtom, r ConditionAtom) Condition {
 	return valuedBinaryWhereClause{l: l, r: r, op: whereOpIs}
 }
@@ -181,6 +472,116 @@ func NewConditionIsNot(l ConditionAtom, r ConditionAtom) Condition {
 	return valuedBinaryWhereClause{l: l, r: r, op: whereOpIsNot}
 }
 
+// NewConditionEqual renders (l = r)
+func NewConditionEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpEqual}
+}
+
+// NewConditionNotEqual renders (l != r)
+func NewConditionNotEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpNotEqual}
+}
+
+// NewConditionExactEqual renders (l == r)
+func NewConditionExactEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpExactEqual}
+}
+
+// NewConditionAnyEqual renders (l ?= r)
+func NewConditionAnyEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpAnyEqual}
+}
+
+// NewConditionAllEqual renders (l *= r)
+func NewConditionAllEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpAllEqual}
+}
+
+// NewConditionLessThan renders (l < r)
+func NewConditionLessThan(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpLessThan}
+}
+
+// NewConditionLessThanOrEqual renders (l <= r)
+func NewConditionLessThanOrEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpLessThanOrEqual}
+}
+
+// NewConditionGreaterThan renders (l > r)
+func NewConditionGreaterThan(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpGreaterThan}
+}
+
+// NewConditionGreaterThanOrEqual renders (l >= r)
+func NewConditionGreaterThanOrEqual(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpGreaterThanOrEqual}
+}
+
+// NewConditionFuzzy renders (l ~ r)
+func NewConditionFuzzy(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpFuzzy}
+}
+
+// NewConditionNotFuzzy renders (l !~ r)
+func NewConditionNotFuzzy(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpNotFuzzy}
+}
+
+// NewConditionContains renders (l CONTAINS r)
+func NewConditionContains(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpContains}
+}
+
+// NewConditionContainsAll renders (l CONTAINSALL r)
+func NewConditionContainsAll(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpContainsAll}
+}
+
+// NewConditionContainsAny renders (l CONTAINSANY r)
+func NewConditionContainsAny(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpContainsAny}
+}
+
+// NewConditionContainsNone renders (l CONTAINSNONE r)
+func NewConditionContainsNone(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpContainsNone}
+}
+
+// NewConditionInside renders (l INSIDE r)
+func NewConditionInside(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpInside}
+}
+
+// NewConditionAllInside renders (l ALLINSIDE r)
+func NewConditionAllInside(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpAllInside}
+}
+
+// NewConditionAnyInside renders (l ANYINSIDE r)
+func NewConditionAnyInside(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpAnyInside}
+}
+
+// NewConditionNoneInside renders (l NONEINSIDE r)
+func NewConditionNoneInside(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpNoneInside}
+}
+
+// NewConditionOutside renders (l OUTSIDE r)
+func NewConditionOutside(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpOutside}
+}
+
+// NewConditionIntersects renders (l INTERSECTS r)
+func NewConditionIntersects(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpIntersects}
+}
+
+// NewConditionMatches renders the full-text search MATCHES operator (l @@ r)
+func NewConditionMatches(l ConditionAtom, r ConditionAtom) Condition {
+	return valuedBinaryWhereClause{l: l, r: r, op: whereOpMatches}
+}
+
 func NewConditionAnd(c0 Condition, c ...Condition) Condition {
 	return newRecursiveCondition(whereOpAnd, c0, c...)
 }
@@ -189,6 +590,46 @@ func NewConditionOr(c0 Condition, c ...Condition) Condition {
 	return newRecursiveCondition(whereOpOr, c0, c...)
 }
 
+// NewProjectionAs renders the aliased projection p AS alias
+func NewProjectionAs(p Projection, alias Field) Projection {
+	return valuedAliasWhereClause{p: p, alias: alias}
+}
+
+// NewAggregateCount renders count()
+func NewAggregateCount() Projection {
+	return valuedFunctionWhereClause{name: "count"}
+}
+
+// NewAggregateSum renders math::sum(p)
+func NewAggregateSum(p Projection) Projection {
+	return valuedFunctionWhereClause{name: "math::sum", args: []valuedWhereClause{p}}
+}
+
+// NewAggregateMean renders math::mean(p)
+func NewAggregateMean(p Projection) Projection {
+	return valuedFunctionWhereClause{name: "math::mean", args: []valuedWhereClause{p}}
+}
+
+// NewAggregateMax renders math::max(p)
+func NewAggregateMax(p Projection) Projection {
+	return valuedFunctionWhereClause{name: "math::max", args: []valuedWhereClause{p}}
+}
+
+// NewAggregateMin renders math::min(p)
+func NewAggregateMin(p Projection) Projection {
+	return valuedFunctionWhereClause{name: "math::min", args: []valuedWhereClause{p}}
+}
+
+// NewAggregateArrayGroup renders array::group(p)
+func NewAggregateArrayGroup(p Projection) Projection {
+	return valuedFunctionWhereClause{name: "array::group", args: []valuedWhereClause{p}}
+}
+
+// NewConditionNot renders the negation !(c)
+func NewConditionNot(c Condition) Condition {
+	return valuedUnaryWhereClause{c: c, op: whereOpNot}
+}
+
 func newBinaryCondition(l Condition, r Condition, op whereOp) Condition {
 	return valuedBinaryWhereClause{op: op, l: l, r: r}
 }
@@ -204,8 +645,28 @@ func newRecursiveCondition(op whereOp, c0 Condition, c ...Condition) Condition {
 }
 
 type selectOrderBy struct {
-	order selectOrder
-	field Field
+	order   selectOrder
+	field   Field
+	collate bool
+	numeric bool
+	rand    bool
+}
+
+func (o selectOrderBy) String() string {
+	if o.rand {
+		return "RAND()"
+	}
+	b := strings.Builder{}
+	b.WriteString(o.field.String())
+	if o.collate {
+		b.WriteString(" COLLATE")
+	}
+	if o.numeric {
+		b.WriteString(" NUMERIC")
+	}
+	b.WriteString(" ")
+	b.WriteString(string(o.order))
+	return b.String()
 }
 
 type selectOrder string
@@ -221,17 +682,104 @@ type whereClause interface {
 
 func (q selectStatement) String() string {
 	b := strings.Builder{}
-	b.WriteString("SELECT * FROM ")
+	b.WriteString("SELECT ")
+	if q.value {
+		b.WriteString("VALUE ")
+	}
+	if len(q.fields) == 0 {
+		b.WriteString("*")
+	}
+	for i, f := range q.fields {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(f.String())
+	}
+	b.WriteString(" FROM ")
 	b.WriteString(string(q.from))
 	if q.where != nil {
 		b.WriteString(" WHERE ")
 		b.WriteString(q.where.String())
 	}
-	if q.orderBy != nil {
-		b.WriteString(" ORDER BY ")
-		b.WriteString(q.orderBy.field.String())
-		b.WriteString(" ")
-		b.WriteString(string(q.orderBy.order))
+	if q.groupAll {
+		b.WriteString(" GROUP ALL")
+	}
+	for i, f := range q.groupBy {
+		if i == 0 {
+			b.WriteString(" GROUP BY ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(f.String())
+	}
+	for i, o := range q.orderBy {
+		if i == 0 {
+			b.WriteString(" ORDER BY ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(o.String())
+	}
+	if q.limit > 0 {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(q.limit))
+	}
+	if q.start > 0 {
+		b.WriteString(" START ")
+		b.WriteString(strconv.Itoa(q.start))
+	}
+	for i, f := range q.fetch {
+		if i == 0 {
+			b.WriteString(" FETCH ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(f.String())
+	}
+	b.WriteString(q.statementOptions.String())
+	return b.String()
+}
+
+// statementOptions renders the TIMEOUT and PARALLEL clauses of a statement
+type statementOptions struct {
+	timeout  time.Duration
+	parallel bool
+}
+
+func (o statementOptions) String() string {
+	b := strings.Builder{}
+	if o.timeout > 0 {
+		b.WriteString(" TIMEOUT ")
+		b.WriteString(durationString(o.timeout))
+	}
+	if o.parallel {
+		b.WriteString(" PARALLEL")
+	}
+	return b.String()
+}
+
+// durationString formats d as a SurrealQL duration such as 1h30m or 1s500ms
+func durationString(d time.Duration) string {
+	if d <= 0 {
+		return "0ns"
+	}
+	b := strings.Builder{}
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "us"},
+		{time.Nanosecond, "ns"},
+	} {
+		if n := d / unit.d; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10))
+			b.WriteString(unit.name)
+			d -= n * unit.d
+		}
 	}
 	return b.String()
 }
@@ -240,6 +788,25 @@ func (w binaryWhereClause) String() string {
 	return fmt.Sprintf("(%s %s %s)", w.l.String(), w.op, w.r.String())
 }
 
+func (w unaryWhereClause) String() string {
+	if _, ok := w.c.(binaryWhereClause); ok {
+		return fmt.Sprintf("%s%s", w.op, w.c.String())
+	}
+	return fmt.Sprintf("%s(%s)", w.op, w.c.String())
+}
+
+func (w functionWhereClause) String() string {
+	args := make([]string, len(w.args))
+	for i, arg := range w.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", w.name, strings.Join(args, ", "))
+}
+
+func (w aliasWhereClause) String() string {
+	return fmt.Sprintf("%s AS %s", w.p.String(), w.alias)
+}
+
 type whereOp string
 
 const (
@@ -247,7 +814,33 @@ const (
 	whereOpAnd   = whereOp("AND")
 	whereOpIs    = whereOp("IS")
 	whereOpIsNot = whereOp("IS NOT")
-	// TODO https://surrealdb.com/docs/surrealql/operators
+	whereOpNot   = whereOp("!")
+)
+
+// https://surrealdb.com/docs/surrealql/operators
+const (
+	whereOpEqual              = whereOp("=")
+	whereOpNotEqual           = whereOp("!=")
+	whereOpExactEqual         = whereOp("==")
+	whereOpAnyEqual           = whereOp("?=")
+	whereOpAllEqual           = whereOp("*=")
+	whereOpLessThan           = whereOp("<")
+	whereOpLessThanOrEqual    = whereOp("<=")
+	whereOpGreaterThan        = whereOp(">")
+	whereOpGreaterThanOrEqual = whereOp(">=")
+	whereOpFuzzy              = whereOp("~")
+	whereOpNotFuzzy           = whereOp("!~")
+	whereOpContains           = whereOp("CONTAINS")
+	whereOpContainsAll        = whereOp("CONTAINSALL")
+	whereOpContainsAny        = whereOp("CONTAINSANY")
+	whereOpContainsNone       = whereOp("CONTAINSNONE")
+	whereOpInside             = whereOp("INSIDE")
+	whereOpAllInside          = whereOp("ALLINSIDE")
+	whereOpAnyInside          = whereOp("ANYINSIDE")
+	whereOpNoneInside         = whereOp("NONEINSIDE")
+	whereOpOutside            = whereOp("OUTSIDE")
+	whereOpIntersects         = whereOp("INTERSECTS")
+	whereOpMatches            = whereOp("@@")
 )
 
 type binaryWhereClause struct {
@@ -258,13 +851,38 @@ type binaryWhereClause struct {
 	log *zerolog.Logger
 }
 
+type functionWhereClause struct {
+	name string
+	args []whereClause
+}
+
+type aliasWhereClause struct {
+	p     whereClause
+	alias Field
+}
+
+type unaryWhereClause struct {
+	c  whereClause
+	op whereOp
+}
+
 var (
 	_ whereClause = binaryWhereClause{}
+	_ whereClause = unaryWhereClause{}
+	_ whereClause = aliasWhereClause{}
+	_ whereClause = functionWhereClause{}
 	_ whereClause = fieldWhereClause(Field(""))
 	_ whereClause = varWhereClause("")
+	_ whereClause = subqueryWhereClause{}
 	_ whereClause = boolWhereClause(false)
 )
 
+type subqueryWhereClause struct{ q whereClause }
+
+func (c subqueryWhereClause) String() string {
+	return "(" + c.q.String() + ")"
+}
+
 type boolWhereClause bool
 
 func (c boolWhereClause) String() string {
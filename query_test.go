@@ -2,8 +2,10 @@ package surrealhigh
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewQueryFrom(t *testing.T) {
@@ -111,12 +113,306 @@ func TestQueryString(t *testing.T) {
 				op: whereOpIs,
 				r:  idVar,
 			},
-			orderBy: &selectOrderBy{
+			orderBy: []selectOrderBy{{
 				order: selectOrderAsc,
 				field: timestampField,
-			},
+			}},
 			from: recordsTable,
 		}
 		assert.Equal(t, "SELECT * FROM records WHERE (record_id IS $id) ORDER BY timestamp ASC", q.String())
 	})
 }
+
+func TestConditionOperators(t *testing.T) {
+	for _, test := range []struct {
+		op        string
+		condition func(ConditionAtom, ConditionAtom) Condition
+	}{
+		{"IS", NewConditionIs},
+		{"IS NOT", NewConditionIsNot},
+		{"=", NewConditionEqual},
+		{"!=", NewConditionNotEqual},
+		{"==", NewConditionExactEqual},
+		{"?=", NewConditionAnyEqual},
+		{"*=", NewConditionAllEqual},
+		{"<", NewConditionLessThan},
+		{"<=", NewConditionLessThanOrEqual},
+		{">", NewConditionGreaterThan},
+		{">=", NewConditionGreaterThanOrEqual},
+		{"~", NewConditionFuzzy},
+		{"!~", NewConditionNotFuzzy},
+		{"CONTAINS", NewConditionContains},
+		{"CONTAINSALL", NewConditionContainsAll},
+		{"CONTAINSANY", NewConditionContainsAny},
+		{"CONTAINSNONE", NewConditionContainsNone},
+		{"INSIDE", NewConditionInside},
+		{"ALLINSIDE", NewConditionAllInside},
+		{"ANYINSIDE", NewConditionAnyInside},
+		{"NONEINSIDE", NewConditionNoneInside},
+		{"OUTSIDE", NewConditionOutside},
+		{"INTERSECTS", NewConditionIntersects},
+		{"@@", NewConditionMatches},
+	} {
+		t.Run(test.op, func(t *testing.T) {
+			c := test.condition(NewConditionAtomField(Field("f")), NewConditionAtomVar("v", 1))
+			assert.Equal(t, "(f "+test.op+" $v)", c.String())
+			assert.Equal(t, []conditionAtomVar{{name: varWhereClause("v"), value: 1}}, c.valuedVars())
+			q := NewQueryFrom(Table("t"), QueryOptionWhere(c))
+			assert.Equal(t, "SELECT * FROM t WHERE (f "+test.op+" $v)", q.String())
+		})
+	}
+}
+
+func TestNewConditionNot(t *testing.T) {
+	t.Run("not (a and b)", func(t *testing.T) {
+		c := NewConditionNot(NewConditionAnd(
+			NewConditionIs(NewConditionAtomField(Field("a")), NewConditionAtomVar("a", 0)),
+			NewConditionIs(NewConditionAtomField(Field("b")), NewConditionAtomVar("b", 1)),
+		))
+		assert.Equal(t, "!((a IS $a) AND (b IS $b))", c.String())
+		assert.Equal(t, []conditionAtomVar{
+			{name: varWhereClause("a"), value: 0},
+			{name: varWhereClause("b"), value: 1},
+		}, c.valuedVars())
+	})
+	t.Run("not not a", func(t *testing.T) {
+		c := NewConditionNot(NewConditionNot(
+			NewConditionIs(NewConditionAtomField(Field("a")), NewConditionAtomVar("a", nil))))
+		assert.Equal(t, "!(!(a IS $a))", c.String())
+	})
+	t.Run("select where not", func(t *testing.T) {
+		q := NewQueryFrom(Table("records"), QueryOptionWhere(NewConditionNot(
+			NewConditionIs(NewConditionAtomField(Field("is_out")), NewConditionAtomVar("out", true)))))
+		assert.Equal(t, "SELECT * FROM records WHERE !(is_out IS $out)", q.String())
+	})
+}
+
+func TestQueryOptionFields(t *testing.T) {
+	t.Run("select id from records", func(t *testing.T) {
+		q := NewQueryFrom(Table("records"), QueryOptionFields(Field("id")))
+		assert.Equal(t, "SELECT id FROM records", q.String())
+	})
+	t.Run("select id, record_id as rid from records", func(t *testing.T) {
+		q := NewQueryFrom(Table("records"), QueryOptionFields(
+			Field("id"), NewProjectionAs(Field("record_id"), Field("rid"))))
+		assert.Equal(t, "SELECT id, record_id AS rid FROM records", q.String())
+	})
+	t.Run("select value id from records where is_out is $out", func(t *testing.T) {
+		q := NewQueryFrom(Table("records"), QueryOptionValue(Field("id")), QueryOptionWhere(
+			NewConditionIs(NewConditionAtomField(Field("is_out")), NewConditionAtomVar("out", true))))
+		assert.Equal(t, "SELECT VALUE id FROM records WHERE (is_out IS $out)", q.String())
+		assert.Equal(t, []conditionAtomVar{{name: varWhereClause("out"), value: true}}, q.valuedVars())
+	})
+	t.Run("no where no vars", func(t *testing.T) {
+		assert.Empty(t, NewQueryFrom(Table("records")).valuedVars())
+	})
+	t.Run("derived twice", func(t *testing.T) {
+		base := NewQueryFrom(Table("records"), QueryOptionFields(Field("a"), Field("b"), Field("c")))
+		x := QueryOptionFields(Field("x"))(base)
+		y := QueryOptionFields(Field("y"))(base)
+		assert.Equal(t, "SELECT a, b, c FROM records", base.String())
+		assert.Equal(t, "SELECT a, b, c, x FROM records", x.String())
+		assert.Equal(t, "SELECT a, b, c, y FROM records", y.String())
+	})
+}
+
+func TestQueryOptionLimitStart(t *testing.T) {
+	t.Run("select * from logs order by timestamp asc limit 10 start 20", func(t *testing.T) {
+		q := NewQueryFrom(Table("logs"),
+			QueryOptionOrderByAsc(Field("timestamp")), QueryOptionLimit(10), QueryOptionStart(20))
+		assert.Equal(t, "SELECT * FROM logs ORDER BY timestamp ASC LIMIT 10 START 20", q.String())
+	})
+	t.Run("select * from logs limit 10", func(t *testing.T) {
+		q := NewQueryFrom(Table("logs"), QueryOptionLimit(10))
+		assert.Equal(t, "SELECT * FROM logs LIMIT 10", q.String())
+	})
+}
+
+func TestQueryOptionOrderBy(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		opts []QueryOption
+		sql  string
+	}{
+		{
+			name: "order by tenant, created_at",
+			opts: []QueryOption{
+				QueryOptionOrderByAsc(Field("tenant")),
+				QueryOptionOrderByDesc(Field("created_at")),
+			},
+			sql: "SELECT * FROM records ORDER BY tenant ASC, created_at DESC",
+		},
+		{
+			name: "order by collate numeric",
+			opts: []QueryOption{
+				QueryOptionOrderByAsc(Field("name"), OrderOptionCollate()),
+				QueryOptionOrderByDesc(Field("code"), OrderOptionNumeric()),
+				QueryOptionOrderByAsc(Field("label"), OrderOptionCollate(), OrderOptionNumeric()),
+			},
+			sql: "SELECT * FROM records ORDER BY name COLLATE ASC, code NUMERIC DESC, label COLLATE NUMERIC ASC",
+		},
+		{
+			name: "order by rand",
+			opts: []QueryOption{QueryOptionOrderByRand(), QueryOptionLimit(1)},
+			sql:  "SELECT * FROM records ORDER BY RAND() LIMIT 1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sql, NewQueryFrom(Table("records"), test.opts...).String())
+		})
+	}
+	t.Run("derived twice", func(t *testing.T) {
+		base := NewQueryFrom(Table("records"),
+			QueryOptionOrderByAsc(Field("a")), QueryOptionOrderByAsc(Field("b")), QueryOptionOrderByAsc(Field("c")))
+		x := QueryOptionOrderByDesc(Field("x"))(base)
+		y := QueryOptionOrderByRand()(base)
+		assert.Equal(t, "SELECT * FROM records ORDER BY a ASC, b ASC, c ASC", base.String())
+		assert.Equal(t, "SELECT * FROM records ORDER BY a ASC, b ASC, c ASC, x DESC", x.String())
+		assert.Equal(t, "SELECT * FROM records ORDER BY a ASC, b ASC, c ASC, RAND()", y.String())
+	})
+}
+
+func TestQueryOptionGroup(t *testing.T) {
+	t.Run("select tenant, count() as n, math::sum(amount) as total from orders group by tenant", func(t *testing.T) {
+		q := NewQueryFrom(Table("orders"),
+			QueryOptionFields(
+				Field("tenant"),
+				NewProjectionAs(NewAggregateCount(), Field("n")),
+				NewProjectionAs(NewAggregateSum(Field("amount")), Field("total")),
+			),
+			QueryOptionGroupBy(Field("tenant")),
+			QueryOptionOrderByAsc(Field("tenant")),
+		)
+		assert.Equal(t, "SELECT tenant, count() AS n, math::sum(amount) AS total FROM orders GROUP BY tenant ORDER BY tenant ASC", q.String())
+	})
+	t.Run("group all", func(t *testing.T) {
+		q := NewQueryFrom(Table("orders"),
+			QueryOptionFields(
+				NewProjectionAs(NewAggregateMean(Field("amount")), Field("mean")),
+				NewProjectionAs(NewAggregateMax(Field("amount")), Field("max")),
+				NewProjectionAs(NewAggregateMin(Field("amount")), Field("min")),
+				NewProjectionAs(NewAggregateArrayGroup(Field("tenant")), Field("tenants")),
+			),
+			QueryOptionWhere(NewConditionGreaterThan(Field("amount"), NewConditionAtomVar("amount", 0))),
+			QueryOptionGroupAll(),
+		)
+		assert.Equal(t, "SELECT math::mean(amount) AS mean, math::max(amount) AS max, math::min(amount) AS min, array::group(tenant) AS tenants FROM orders WHERE (amount > $amount) GROUP ALL", q.String())
+	})
+	t.Run("group by fields", func(t *testing.T) {
+		q := NewQueryFrom(Table("orders"), QueryOptionGroupBy(Field("tenant"), Field("day")))
+		assert.Equal(t, "SELECT * FROM orders GROUP BY tenant, day", q.String())
+	})
+	t.Run("derived twice", func(t *testing.T) {
+		base := NewQueryFrom(Table("orders"),
+			QueryOptionGroupBy(Field("a")), QueryOptionGroupBy(Field("b")), QueryOptionGroupBy(Field("c")))
+		x := QueryOptionGroupBy(Field("x"))(base)
+		y := QueryOptionGroupBy(Field("y"))(base)
+		assert.Equal(t, "SELECT * FROM orders GROUP BY a, b, c", base.String())
+		assert.Equal(t, "SELECT * FROM orders GROUP BY a, b, c, x", x.String())
+		assert.Equal(t, "SELECT * FROM orders GROUP BY a, b, c, y", y.String())
+	})
+}
+
+func TestQueryOptionFetch(t *testing.T) {
+	t.Run("fetch author, tags", func(t *testing.T) {
+		q := NewQueryFrom(Table("post"), QueryOptionLimit(10), QueryOptionFetch(Field("author"), Field("tags")))
+		assert.Equal(t, "SELECT * FROM post LIMIT 10 FETCH author, tags", q.String())
+	})
+	t.Run("derived twice", func(t *testing.T) {
+		base := NewQueryFrom(Table("post"),
+			QueryOptionFetch(Field("a")), QueryOptionFetch(Field("b")), QueryOptionFetch(Field("c")))
+		x := QueryOptionFetch(Field("x"))(base)
+		y := QueryOptionFetch(Field("y"))(base)
+		assert.Equal(t, "SELECT * FROM post FETCH a, b, c", base.String())
+		assert.Equal(t, "SELECT * FROM post FETCH a, b, c, x", x.String())
+		assert.Equal(t, "SELECT * FROM post FETCH a, b, c, y", y.String())
+	})
+}
+
+func TestQueryOptionTimeout(t *testing.T) {
+	t.Run("timeout parallel after fetch", func(t *testing.T) {
+		q := NewQueryFrom(Table("post"), QueryOptionParallel(), QueryOptionTimeout(5*time.Second), QueryOptionFetch(Field("author")))
+		assert.Equal(t, "SELECT * FROM post FETCH author TIMEOUT 5s PARALLEL", q.String())
+	})
+	t.Run("no timeout", func(t *testing.T) {
+		q := NewQueryFrom(Table("post"), QueryOptionTimeout(0))
+		assert.Equal(t, "SELECT * FROM post", q.String())
+	})
+}
+
+func TestDurationString(t *testing.T) {
+	for _, test := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 90 * time.Minute, want: "1h30m"},
+		{d: 1500 * time.Millisecond, want: "1s500ms"},
+		{d: 250 * time.Millisecond, want: "250ms"},
+		{d: 42 * time.Nanosecond, want: "42ns"},
+		{d: 0, want: "0ns"},
+	} {
+		assert.Equal(t, test.want, durationString(test.d), test.d.String())
+	}
+}
+
+func TestNewConditionValue(t *testing.T) {
+	published := func() Condition {
+		return NewConditionEqual(Field("published"), NewConditionValue(true))
+	}
+	visible := func() Condition {
+		return NewConditionEqual(Field("visible"), NewConditionValue(true))
+	}
+	q := NewQueryFrom(Table("post"), QueryOptionWhere(NewConditionAnd(
+		NewConditionAnd(published(), visible()),
+		NewConditionGreaterThan(Field("likes"), NewConditionValue(10)),
+		NewConditionIs(Field("author"), NewConditionAtomVar("author", "a")))))
+	assert.Equal(t, "SELECT * FROM post WHERE (((published = $v_5b5c98ef514dbfa5) AND (visible = $v_5b5c98ef514dbfa5)) AND ((likes > $v_07f89207b4ba08a4) AND (author IS $author)))", q.String())
+	vars, err := valuate(q.valuedVars())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"v_5b5c98ef514dbfa5": true, "v_07f89207b4ba08a4": 10, "author": "a"}, vars)
+
+	t.Run("named var keeps failing on duplicates", func(t *testing.T) {
+		c := NewConditionAnd(
+			NewConditionIs(Field("a"), NewConditionAtomVar("v_5b5c98ef514dbfa5", true)),
+			NewConditionIs(Field("b"), NewConditionValue(true)))
+		_, err := valuate(c.valuedVars())
+		assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+	})
+	t.Run("colliding names fail", func(t *testing.T) {
+		_, err := valuate([]conditionAtomVar{
+			{name: "v_5b5c98ef514dbfa5", value: true, encoded: []byte("true")},
+			{name: "v_5b5c98ef514dbfa5", value: false, encoded: []byte("false")},
+		})
+		assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+	})
+}
+
+func TestSelect_subquery(t *testing.T) {
+	active := NewQueryFrom(Table("person"),
+		QueryOptionValue(Field("id")),
+		QueryOptionWhere(NewConditionEqual(Field("active"), NewConditionAtomVar("active", true))))
+	t.Run("condition operand", func(t *testing.T) {
+		q := NewQueryFrom(Table("post"), QueryOptionWhere(NewConditionAnd(
+			NewConditionInside(Field("author"), active),
+			NewConditionEqual(Field("published"), NewConditionAtomVar("published", true)))))
+		assert.Equal(t, "SELECT * FROM post WHERE ((author INSIDE (SELECT VALUE id FROM person WHERE (active = $active))) AND (published = $published))", q.String())
+		vars, err := valuate(q.valuedVars())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"active": true, "published": true}, vars)
+	})
+	t.Run("projection", func(t *testing.T) {
+		likes := NewQueryFrom(Table("likes"),
+			QueryOptionFields(NewAggregateCount()),
+			QueryOptionWhere(NewConditionEqual(Field("out"), NewConditionAtomField(Field("$parent.id")))),
+			QueryOptionGroupAll())
+		q := NewQueryFrom(Table("post"), QueryOptionFields(Field("title"), NewProjectionAs(likes, Field("likes"))))
+		assert.Equal(t, "SELECT title, (SELECT count() FROM likes WHERE (out = $parent.id) GROUP ALL) AS likes FROM post", q.String())
+	})
+	t.Run("duplicate valuation", func(t *testing.T) {
+		q := NewQueryFrom(Table("post"), QueryOptionWhere(NewConditionAnd(
+			NewConditionInside(Field("author"), active),
+			NewConditionEqual(Field("active"), NewConditionAtomVar("active", false)))))
+		_, err := valuate(q.valuedVars())
+		assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+	})
+}
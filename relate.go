@@ -0,0 +1,79 @@
+package surrealhigh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Relation is a RELATE statement creating an edge of table edge from a record
+// to another one, content is the edge document
+type Relation[E Doc] struct {
+	from    Thing
+	edge    Table
+	id      Id
+	to      Thing
+	content ConditionAtomVar
+}
+
+// Relate renders RELATE (type::thing($tb, $id))->edge:id->(type::thing($tb, $id))
+// CONTENT $content where the edge id is new; from, to and the content are
+// bound with NewConditionValue
+func Relate[E Doc](from Thing, edge Table, to Thing, content E) Relation[E] {
+	return Relation[E]{
+		from:    from,
+		edge:    edge,
+		id:      NewID(),
+		to:      to,
+		content: NewConditionValue(content),
+	}
+}
+
+// Thing is the edge created
+func (r Relation[E]) Thing() Thing {
+	return r.id.Thing(r.edge)
+}
+
+func (r Relation[E]) String() string {
+	b := strings.Builder{}
+	b.WriteString("RELATE (")
+	b.WriteString(thingExpression(r.from).String())
+	b.WriteString(")->")
+	b.WriteString(r.Thing().String())
+	b.WriteString("->(")
+	b.WriteString(thingExpression(r.to).String())
+	b.WriteString(") CONTENT ")
+	b.WriteString(r.content.String())
+	return b.String()
+}
+
+func (r Relation[E]) valuedVars() []conditionAtomVar {
+	vars := thingExpression(r.from).valuedVars()
+	vars = append(vars, thingExpression(r.to).valuedVars()...)
+	return append(vars, r.content.valuedVars()...)
+}
+
+type DBRelate interface {
+	// Do returns the id of the created edge with the following errors
+	//  - any error from DBSelect.Do
+	Do() (Id, error)
+}
+
+func RelateOn[E Doc](r Relation[E], db SurrealDriver) DBRelate {
+	return DBRelate(dbRelate[E]{
+		relation: r,
+		db:       db,
+	})
+}
+
+type dbRelate[E Doc] struct {
+	relation Relation[E]
+	db       SurrealDriver
+}
+
+func (r dbRelate[E]) Do() (Id, error) {
+	if _, err := query[struct{}](context.Background(), r.db, r.relation.String(), r.relation.valuedVars()); err != nil {
+		return nilID, fmt.Errorf("relate %q: %w", r.relation.edge, err)
+	}
+	return r.relation.id, nil
+}
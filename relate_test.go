@@ -0,0 +1,72 @@
+package surrealhigh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEdge struct {
+	At int `json:"at"`
+}
+
+func (mockEdge) Table() Table { return "likes" }
+
+func TestRelate_String(t *testing.T) {
+	r := Relate(Thing("person:a"), Table("likes"), Thing("post:b"), mockEdge{At: 1})
+	assert.True(t, strings.HasPrefix(r.Thing().String(), "likes:"))
+	_, err := NewIDFromThing(r.Thing(), "likes")
+	require.NoError(t, err)
+	assert.Equal(t, "RELATE (type::thing($v_4931e243e9dea3e6, $v_d4272417d7c77eea))->"+r.Thing().String()+
+		"->(type::thing($v_3759454898d6e5df, $v_d4311617d7cfbba5)) CONTENT $v_a3a0075239ad76f3", r.String())
+	vars, err := valuate(r.valuedVars())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"v_4931e243e9dea3e6": "person",
+		"v_d4272417d7c77eea": "a",
+		"v_3759454898d6e5df": "post",
+		"v_d4311617d7cfbba5": "b",
+		"v_a3a0075239ad76f3": mockEdge{At: 1},
+	}, vars)
+}
+
+func TestRelate_injection(t *testing.T) {
+	from := Thing("person:a->likes->post:b; DELETE person; SELECT * FROM x")
+	r := Relate(from, Table("likes"), Thing("post:b"), mockEdge{At: 1})
+	assert.NotContains(t, r.String(), "DELETE")
+	vars, err := valuate(r.valuedVars())
+	require.NoError(t, err)
+	assert.Contains(t, vars, "v_4931e243e9dea3e6")
+	assert.Equal(t, "person", vars["v_4931e243e9dea3e6"])
+	assert.Contains(t, r.String(), "type::thing($v_4931e243e9dea3e6, ")
+	id := NewConditionValue("a->likes->post:b; DELETE person; SELECT * FROM x")
+	assert.Contains(t, r.String(), id.String()+"))->")
+}
+
+func TestDBRelate_Do(t *testing.T) {
+	t.Run("edge id", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{
+			"result": []interface{}{map[string]interface{}{"id": "likes:8zt4b5x0k2m1"}},
+			"status": "OK",
+		}})
+		r := Relate(Thing("person:a"), Table("likes"), Thing("post:b"), mockEdge{At: 1})
+		id, err := RelateOn(r, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, r.Thing(), id.Thing("likes"))
+		assert.Equal(t, r.String(), *db.sql)
+		assert.Equal(t, map[string]interface{}{
+			"v_4931e243e9dea3e6": "person",
+			"v_d4272417d7c77eea": "a",
+			"v_3759454898d6e5df": "post",
+			"v_d4311617d7cfbba5": "b",
+			"v_a3a0075239ad76f3": mockEdge{At: 1},
+		}, *db.vars)
+	})
+	t.Run("no result", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		_, err := RelateOn(Relate(Thing("person:a"), Table("likes"), Thing("post:b"), mockEdge{}), db).Do()
+		assert.ErrorIs(t, err, ErrNoResult)
+	})
+}
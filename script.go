@@ -0,0 +1,100 @@
+package surrealhigh
+
+import "strings"
+
+// Script is a Batch which statements may bind parameters with LET and use
+// them later on, it runs in a single query
+//
+//	s := NewScript(db)
+//	authors := s.Let("authors", NewQueryFrom(Table("person"), QueryOptionValue(Field("id"))))
+//	posts := s.Add(NewQueryFrom(Table("post"),
+//		QueryOptionWhere(NewConditionInside(Field("author"), authors))))
+//	results, err := s.Run()
+//	docs, err := ResultOf[D](results, posts)
+//
+// The variables of the statements are collected as in a Batch; a parameter
+// named as one of them fails with ErrDuplicateValuation.
+type Script struct{ Batch }
+
+func NewScript(db SurrealDriver) *Script {
+	return &Script{Batch{db: db}}
+}
+
+// Run runs the script as Batch.Run does, it also fails with
+// ErrDuplicateValuation when a parameter is named as a variable
+func (s *Script) Run() (Results, error) {
+	params := make(map[string]bool)
+	for _, statement := range s.statements {
+		if l, ok := statement.(Let); ok {
+			params[string(l.name)] = true
+		}
+	}
+	var duplicates []conditionAtomVar
+	for _, statement := range s.statements {
+		for _, v := range statement.valuedVars() {
+			if params[v.name.Var()] {
+				duplicates = append(duplicates, v)
+			}
+		}
+	}
+	if len(duplicates) > 0 {
+		return nil, ErrDuplicateValuation{duplicates}
+	}
+	return s.Batch.Run()
+}
+
+// Let appends LET $name = v to the script and returns the parameter $name
+func (s *Script) Let(name string, v ConditionAtom) Param {
+	s.Add(NewLet(name, v))
+	return Param(name)
+}
+
+// Let is a LET statement binding the parameter $name to a value, a Select or
+// any expression
+type Let struct {
+	name Param
+	v    valuedWhereClause
+}
+
+var _ Statement = Let{}
+
+// NewLet renders LET $name = v
+func NewLet(name string, v ConditionAtom) Let {
+	return Let{name: Param(name), v: v}
+}
+
+func (l Let) String() string {
+	b := strings.Builder{}
+	b.WriteString("LET ")
+	b.WriteString(l.name.String())
+	b.WriteString(" = ")
+	b.WriteString(l.v.asWhereClause().String())
+	return b.String()
+}
+
+func (l Let) valuedVars() []conditionAtomVar {
+	return l.v.valuedVars()
+}
+
+// Param is a parameter bound by a LET statement, it is a ConditionAtom and a
+// Projection without value
+type Param string
+
+var _ ConditionAtom = Param("")
+
+func (p Param) String() string {
+	return varWhereClause(p).String()
+}
+
+// Table is the parameter as the target of a statement, e.g. SELECT * FROM $p
+func (p Param) Table() Table {
+	return Table(p.String())
+}
+
+func (p Param) asWhereClause() whereClause {
+	return varWhereClause(p)
+}
+
+func (p Param) valuedVars() []conditionAtomVar {
+	return []conditionAtomVar{}
+}
@@ -0,0 +1,83 @@
+package surrealhigh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLet(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		let  Let
+		sql  string
+	}{
+		{
+			name: "select",
+			let: NewLet("authors", NewQueryFrom(Table("person"),
+				QueryOptionValue(Field("id")),
+				QueryOptionWhere(NewConditionEqual(Field("active"), NewConditionAtomVar("active", true))))),
+			sql: "LET $authors = (SELECT VALUE id FROM person WHERE (active = $active))",
+		},
+		{
+			name: "expression",
+			let:  NewLet("day", NewFunctionTimeFloor(NewFunctionTimeNow(), 24*time.Hour)),
+			sql:  "LET $day = time::floor(time::now(), 24h)",
+		},
+		{
+			name: "value",
+			let:  NewLet("n", NewConditionAtomVar("limit", 10)),
+			sql:  "LET $n = $limit",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sql, test.let.String())
+		})
+	}
+}
+
+func TestScript_Run(t *testing.T) {
+	db := newMockQueryDriver([]interface{}{
+		map[string]interface{}{"result": nil, "status": "OK"},
+		map[string]interface{}{"result": nil, "status": "OK"},
+		map[string]interface{}{"result": []interface{}{map[string]interface{}{"RecordID": 1}}, "status": "OK"},
+		map[string]interface{}{"result": []interface{}{map[string]interface{}{"RecordID": 2}}, "status": "OK"},
+	})
+	s := NewScript(db)
+	authors := s.Let("authors", NewQueryFrom(Table("person"),
+		QueryOptionValue(Field("id")),
+		QueryOptionWhere(NewConditionEqual(Field("active"), NewConditionAtomVar("active", true)))))
+	posts := s.Let("posts", NewQueryFrom(Table("post"),
+		QueryOptionWhere(NewConditionAnd(
+			NewConditionInside(Field("author"), authors),
+			NewConditionGreaterThan(Field("at"), NewConditionAtomVar("since", 0))))))
+	recent := s.Add(NewQueryFrom(posts.Table(), QueryOptionLimit(10)))
+	counted := s.Add(NewQueryFrom(posts.Table(), QueryOptionFields(NewAggregateCount()), QueryOptionGroupAll()))
+	results, err := s.Run()
+	require.NoError(t, err)
+	assert.Equal(t, "LET $authors = (SELECT VALUE id FROM person WHERE (active = $active)); "+
+		"LET $posts = (SELECT * FROM post WHERE ((author INSIDE $authors) AND (at > $since))); "+
+		"SELECT * FROM $posts LIMIT 10; "+
+		"SELECT count() FROM $posts GROUP ALL", *db.sql)
+	assert.Equal(t, map[string]interface{}{"active": true, "since": 0}, *db.vars)
+	docs, err := ResultOf[mockDoc](results, recent)
+	require.NoError(t, err)
+	assert.Equal(t, []mockDoc{{RecordID: 1}}, docs)
+	docs, err = ResultOf[mockDoc](results, counted)
+	require.NoError(t, err)
+	assert.Equal(t, []mockDoc{{RecordID: 2}}, docs)
+}
+
+func TestScript_Run_duplicateValuation(t *testing.T) {
+	db := newMockQueryDriver(nil)
+	s := NewScript(db)
+	active := s.Let("active", NewConditionAtomVar("since", 0))
+	s.Add(NewQueryFrom(Table("person"), QueryOptionWhere(NewConditionAnd(
+		NewConditionInside(Field("id"), active),
+		NewConditionEqual(Field("active"), NewConditionAtomVar("active", true))))))
+	_, err := s.Run()
+	assert.ErrorAs(t, err, &ErrDuplicateValuation{})
+	assert.Empty(t, *db.sql)
+}
@@ -1,6 +1,8 @@
 package surrealhigh
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -18,6 +20,20 @@ type SurrealDriver interface {
 	Driver() SurrealDB
 }
 
+// SurrealDBContext is SurrealDB with context aware methods
+type SurrealDBContext interface {
+	QueryContext(ctx context.Context, sql string, vars interface{}) (interface{}, error)
+	UpdateContext(ctx context.Context, what string, data interface{}) (interface{}, error)
+	CreateContext(ctx context.Context, thing string, data interface{}) (interface{}, error)
+}
+
+// SurrealDriverContext is a SurrealDriver with a context aware driver, a
+// SurrealDriver which is not one is adapted with NewSurrealDBContext
+type SurrealDriverContext interface {
+	SurrealDriver
+	DriverContext() SurrealDBContext
+}
+
 type defaultDriver struct {
 	db *surrealdb.DB
 }
@@ -26,42 +42,109 @@ func (driver defaultDriver) Driver() SurrealDB {
 	return driver.db
 }
 
+func (driver defaultDriver) DriverContext() SurrealDBContext {
+	return NewSurrealDBContext(driver.db)
+}
+
 func DefaultDriver(db *surrealdb.DB) SurrealDriver {
 	return defaultDriver{db}
 }
 
-type DBSelect[D Doc] interface {
+// DBSelect decodes results into D which is either a Doc or any projection
+// type matching the selected fields, e.g. an aggregate row when grouping.
+//
+// Do fails with ErrNoResult when the query matches no record; One, First,
+// Maybe and All tell an empty result apart from a failed query, they return
+// with the errors of Do but ErrNoResult which is then a malformed response.
+type DBSelect[D any] interface {
 	// Do returns with the following errors; in chronological order:
 	// - type ErrDuplicateValuation
 	// - any error from surrealdb.go query driver
 	// - any error from surrealdb.go unmarshal
-	// - ErrNoResult
+	// - type ErrStatement
+	// - ErrNoResult, also when no record matched
+	Do() ([]D, error)
+
+	// DoContext is Do with a context, it also returns with the error of ctx
+	// when ctx is done before the query returns
+	DoContext(ctx context.Context) ([]D, error)
+
+	// Pages walks the results in pages of size; when the query has no ORDER BY
+	// clause, pages are ordered by id so that they are stable; it fails with
+	// ErrPagesOrder for queries ordered by RAND() or grouped
+	Pages(size int) *DBPages[D]
+
+	// One expects exactly one record, it fails with ErrNoDoc when none matched
+	// and with ErrTooMany when more did (LIMIT 2)
+	One() DBSelectOne[D]
+
+	// First returns the first record, it fails with ErrNoDoc when none matched
+	// (LIMIT 1)
+	First() DBSelectOne[D]
+
+	// Maybe expects zero or one record, it returns nil when none matched and
+	// fails with ErrTooMany when more did (LIMIT 2)
+	Maybe() DBSelectMaybe[D]
+
+	// All returns all the records, it returns an empty slice without error
+	// when none matched
+	All() DBSelectAll[D]
+}
+
+// DBSelectOne is a DBSelect for a single record, see DBSelect.One and
+// DBSelect.First for the errors
+type DBSelectOne[D any] interface {
+	Do() (D, error)
+	DoContext(ctx context.Context) (D, error)
+}
+
+// DBSelectMaybe is a DBSelect for an optional record, see DBSelect.Maybe
+type DBSelectMaybe[D any] interface {
+	Do() (*D, error)
+	DoContext(ctx context.Context) (*D, error)
+}
+
+// DBSelectAll is a DBSelect for any number of records, see DBSelect.All
+type DBSelectAll[D any] interface {
 	Do() ([]D, error)
+	DoContext(ctx context.Context) ([]D, error)
 }
 
+// DBSelectAndUpdate selects docs and updates the first one client side.
+//
+// By default nothing protects the update from concurrent writes between the
+// select and the update. SelectAndUpdateOptionTransaction writes the update
+// in a transaction only if the doc still matches the select condition, and
+// fails with ErrConflict otherwise so that the caller can retry. When the
+// update can be expressed server side, prefer UpdateOn with an UPDATE ...
+// WHERE statement which is atomic.
 type DBSelectAndUpdate[D Doc] interface {
 	// Do returns with the following errors
-	// - Any error from DBSelect.Do
-	// - ErrNoDoc
+	// - Any error from DBSelect.First, ErrNoDoc when no doc matched
+	// - ErrConflict, in transaction mode
 	Do() (D, error)
 }
 
-func SelectOn[D Doc](q Select, db SurrealDriver) DBSelect[D] {
+func SelectOn[D any](q Select, db SurrealDriver) DBSelect[D] {
 	return DBSelect[D](dbSelect[D]{
 		query: q,
 		db:    db,
 	})
 }
 
-func SelectAndUpdate[D DocWithID](q Select, update func(D) D, db SurrealDriver) DBSelectAndUpdate[D] {
-	return DBSelectAndUpdate[D](dbSelectUpdate[D]{
+func SelectAndUpdate[D DocWithID](q Select, update func(D) D, db SurrealDriver, opts ...SelectAndUpdateOption) DBSelectAndUpdate[D] {
+	u := dbSelectUpdate[D]{
 		query:  q,
 		update: update,
 		db:     db,
-	})
+	}
+	for _, opt := range opts {
+		u.options = opt(u.options)
+	}
+	return DBSelectAndUpdate[D](u)
 }
 
-type dbSelect[D Doc] struct {
+type dbSelect[D any] struct {
 	query Select
 	db    SurrealDriver
 }
@@ -72,9 +155,10 @@ type DocWithID interface {
 }
 
 type dbSelectUpdate[D DocWithID] struct {
-	query  Select
-	update func(D) D
-	db     SurrealDriver
+	query   Select
+	update  func(D) D
+	db      SurrealDriver
+	options selectAndUpdateOptions
 }
 
 type ErrDuplicateValuation struct {
@@ -90,77 +174,501 @@ func (err ErrDuplicateValuation) Error() string {
 
 }
 
+// valuate maps variable names to their values, vars is nil when there are no
+// variables; it fails with ErrDuplicateValuation when a name is used twice but
+// by generated vars which encode the same value
+func valuate(valued []conditionAtomVar) (map[string]interface{}, error) {
+
+	if len(valued) == 0 {
+		return nil, nil
+	}
+
+	vars := make(map[string]interface{})
+	encoded := make(map[string][]byte)
+
+	var duplicates []conditionAtomVar
+
+	for _, v := range valued {
+		name := v.name.Var()
+		if _, ok := vars[name]; ok && (v.encoded == nil || !bytes.Equal(v.encoded, encoded[name])) {
+			duplicates = append(duplicates, v)
+		}
+		vars[name] = v.value
+		encoded[name] = v.encoded
+	}
+
+	if len(duplicates) > 0 {
+		return nil, ErrDuplicateValuation{duplicates}
+	}
+
+	return vars, nil
+
+}
+
 var (
 	ErrNoResult = errors.New("surrealdb: unmarshal results: no `results`")
 )
 
 func (q dbSelect[D]) Do() ([]D, error) {
+	return q.DoContext(context.Background())
+}
 
-	var vars map[string]interface{}
-
-	if q.query.where != nil {
+func (q dbSelect[D]) DoContext(ctx context.Context) ([]D, error) {
+	return query[D](ctx, q.db, q.query.String(), q.query.valuedVars())
+}
 
-		vars = make(map[string]interface{})
+func (q dbSelect[D]) One() DBSelectOne[D] {
+	return dbSelectOne[D]{q.limitTo(2), false}
+}
 
-		var duplicates []conditionAtomVar
+func (q dbSelect[D]) First() DBSelectOne[D] {
+	return dbSelectOne[D]{q.limitTo(1), true}
+}
 
-		for _, v := range q.query.where.valuedVars() {
-			if _, ok := vars[v.name.Var()]; ok {
-				duplicates = append(duplicates, v)
-			}
-			vars[v.name.Var()] = v.value
-		}
+func (q dbSelect[D]) Maybe() DBSelectMaybe[D] {
+	return dbSelectMaybe[D](q.limitTo(2))
+}
 
-		if len(duplicates) > 0 {
-			return nil, ErrDuplicateValuation{duplicates}
-		}
+func (q dbSelect[D]) All() DBSelectAll[D] {
+	return dbSelectAll[D](q)
+}
 
+// limitTo limits the query to n records unless it is already limited to less
+func (q dbSelect[D]) limitTo(n int) dbSelect[D] {
+	if q.query.limit == 0 || q.query.limit > n {
+		q.query.limit = n
 	}
+	return q
+}
+
+type dbSelectOne[D any] struct {
+	query dbSelect[D]
+	first bool
+}
+
+func (q dbSelectOne[D]) Do() (D, error) {
+	return q.DoContext(context.Background())
+}
 
-	data, err := q.db.Driver().Query(q.query.String(), vars)
+func (q dbSelectOne[D]) DoContext(ctx context.Context) (D, error) {
+	var d D
+	docs, err := dbSelectAll[D](q.query).DoContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("surrealdb: %w", err)
+		return d, err
+	}
+	if len(docs) == 0 {
+		return d, ErrNoDoc
 	}
+	if len(docs) > 1 && !q.first {
+		return d, ErrTooMany
+	}
+	return docs[0], nil
+}
+
+type dbSelectMaybe[D any] dbSelect[D]
 
-	var results []struct {
-		Results []D    `json:"result"`
-		Status  string `json:"status"`
+func (q dbSelectMaybe[D]) Do() (*D, error) {
+	return q.DoContext(context.Background())
+}
+
+func (q dbSelectMaybe[D]) DoContext(ctx context.Context) (*D, error) {
+	docs, err := dbSelectAll[D](q).DoContext(ctx)
+	if err != nil {
+		return nil, err
 	}
+	switch len(docs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &docs[0], nil
+	}
+	return nil, ErrTooMany
+}
 
-	// TODO see if there is a way to use SmartUnmarshal instead
+type dbSelectAll[D any] dbSelect[D]
 
-	if err := surrealdb.Unmarshal(data, &results); err != nil {
-		return nil, fmt.Errorf("surrealdb: unmarshal results: %w", err)
+func (q dbSelectAll[D]) Do() ([]D, error) {
+	return q.DoContext(context.Background())
+}
+
+func (q dbSelectAll[D]) DoContext(ctx context.Context) ([]D, error) {
+	return queryAll[D](ctx, q.db, q.query.String(), q.query.valuedVars())
+}
+
+// query runs the statement sql and decodes its results, it returns with the
+// errors documented on DBSelect.Do
+func query[D any](ctx context.Context, db SurrealDriver, sql string, valued []conditionAtomVar) ([]D, error) {
+
+	docs, err := queryAll[D](ctx, db, sql, valued)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(results) == 0 {
+	if len(docs) == 0 {
 		return nil, ErrNoResult
 	}
 
-	if len(results[0].Results) == 0 {
+	return docs, nil
+
+}
+
+// queryAll is query which does not fail when there is no doc, ErrNoResult is
+// then only a response without statement result
+func queryAll[D any](ctx context.Context, db SurrealDriver, sql string, valued []conditionAtomVar) ([]D, error) {
+
+	results, err := queryStatements(ctx, db, sql, valued)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
 		return nil, ErrNoResult
 	}
 
-	return results[0].Results, nil
+	return DecodeResult[D](results[0].asStatementResult(sql))
 
 }
 
-var ErrNoDoc = errors.New("no document matched")
+var (
+	ErrNoDoc   = errors.New("no document matched")
+	ErrTooMany = errors.New("more than one document matched")
+)
 
 func (u dbSelectUpdate[D]) Do() (D, error) {
 	q, db, update := u.query, u.db, u.update
-	docs, err := SelectOn[D](q, db).Do()
+	doc, err := SelectOn[D](q, db).First().Do()
+	if err != nil {
+		return doc, fmt.Errorf("select on %q: %w", doc.Table(), err)
+	}
+	newDoc := update(doc)
+	if u.options.transaction {
+		if err := updateInTransaction(db, q, []D{doc}, []D{newDoc}); err != nil {
+			return newDoc, fmt.Errorf("transaction: %w", err)
+		}
+		return newDoc, nil
+	}
+	if _, err := db.Driver().Update(doc.Id().String(), newDoc); err != nil {
+		return newDoc, fmt.Errorf("sdb: update %q: %w", doc.Id(), err)
+	}
+	return newDoc, nil
+}
+
+// DBSelectAndUpdateAll selects docs and updates them client side, see
+// DBSelectAndUpdate for the transaction mode
+type DBSelectAndUpdateAll[D Doc] interface {
+	// Do returns the updated docs with the following errors
+	// - Any error from DBSelect.All
+	// - ErrNoDoc
+	// - type ErrPartialUpdate, the returned docs are the ones updated; in
+	//   transaction mode no doc is updated and failures may wrap ErrConflict
+	Do() ([]D, error)
+}
+
+// SelectAndUpdateAll is SelectAndUpdate applying update to every matched doc
+func SelectAndUpdateAll[D DocWithID](q Select, update func(D) D, db SurrealDriver, opts ...SelectAndUpdateOption) DBSelectAndUpdateAll[D] {
+	u := dbSelectUpdateAll[D]{
+		query:  q,
+		update: update,
+		db:     db,
+	}
+	for _, opt := range opts {
+		u.options = opt(u.options)
+	}
+	return DBSelectAndUpdateAll[D](u)
+}
+
+type SelectAndUpdateOption func(selectAndUpdateOptions) selectAndUpdateOptions
+
+type selectAndUpdateOptions struct {
+	transaction bool
+}
+
+// SelectAndUpdateOptionTransaction writes all the updates in a single
+// transaction, either all docs are updated or none; a doc which no longer
+// matches the select condition throws in the transaction which then fails
+// with ErrConflict
+func SelectAndUpdateOptionTransaction() SelectAndUpdateOption {
+	return func(o selectAndUpdateOptions) selectAndUpdateOptions {
+		o.transaction = true
+		return o
+	}
+}
+
+type dbSelectUpdateAll[D DocWithID] struct {
+	query   Select
+	update  func(D) D
+	db      SurrealDriver
+	options selectAndUpdateOptions
+}
+
+// ErrUpdateThing is the failed update of a thing
+type ErrUpdateThing struct {
+	Thing Thing
+	Err   error
+}
+
+func (err ErrUpdateThing) Error() string {
+	return fmt.Sprintf("update %q: %v", err.Thing, err.Err)
+}
+
+func (err ErrUpdateThing) Unwrap() error {
+	return err.Err
+}
+
+// ErrPartialUpdate lists the things which failed to update
+type ErrPartialUpdate struct {
+	Failed []ErrUpdateThing
+}
+
+func (err ErrPartialUpdate) Error() string {
+	var failed []string
+	for _, f := range err.Failed {
+		failed = append(failed, f.Error())
+	}
+	return fmt.Sprintf("%d update(s) failed: %s", len(err.Failed), strings.Join(failed, ", "))
+}
+
+func (err ErrPartialUpdate) Unwrap() []error {
+	errs := make([]error, len(err.Failed))
+	for i, f := range err.Failed {
+		errs[i] = f
+	}
+	return errs
+}
+
+func (u dbSelectUpdateAll[D]) Do() ([]D, error) {
+	q, db, update := u.query, u.db, u.update
+	docs, err := SelectOn[D](q, db).All().Do()
 	if err != nil {
 		var d D
-		return d, fmt.Errorf("select on %q: %w", d.Table(), err)
+		return nil, fmt.Errorf("select on %q: %w", d.Table(), err)
 	}
 	if len(docs) == 0 {
 		var d D
-		return d, fmt.Errorf("select on %q: %w", d.Table(), ErrNoDoc)
+		return nil, fmt.Errorf("select on %q: %w", d.Table(), ErrNoDoc)
 	}
-	newDoc := update(docs[0])
-	if _, err := db.Driver().Update(docs[0].Id().String(), newDoc); err != nil {
-		return newDoc, fmt.Errorf("sdb: update %q: %w", docs[0].Id(), err)
+	newDocs := make([]D, len(docs))
+	for i, doc := range docs {
+		newDocs[i] = update(doc)
 	}
-	return newDoc, nil
+	if u.options.transaction {
+		if err := updateInTransaction(db, q, docs, newDocs); err != nil {
+			return nil, fmt.Errorf("transaction: %w", err)
+		}
+		return newDocs, nil
+	}
+	var (
+		updated []D
+		failed  []ErrUpdateThing
+	)
+	for i, doc := range docs {
+		if _, err := db.Driver().Update(doc.Id().String(), newDocs[i]); err != nil {
+			failed = append(failed, ErrUpdateThing{Thing: doc.Id(), Err: fmt.Errorf("sdb: %w", err)})
+			continue
+		}
+		updated = append(updated, newDocs[i])
+	}
+	if len(failed) > 0 {
+		return updated, ErrPartialUpdate{failed}
+	}
+	return updated, nil
+}
+
+// updateInTransaction writes the new docs in a single transaction if the docs
+// still match the where condition of q, an update which matches nothing throws
+// so that the transaction is cancelled by the server
+//
+//	IF array::len((UPDATE type::thing($tb, $id) CONTENT $doc0 WHERE ...)) = 0 { THROW "conflict: th" }
+func updateInTransaction[D DocWithID](db SurrealDriver, q Select, docs, newDocs []D) error {
+	statements := []string{"BEGIN TRANSACTION"}
+	var vars []conditionAtomVar
+	for i, doc := range docs {
+		content := conditionAtomVar{name: varWhereClause(fmt.Sprintf("doc%d", i)), value: newDocs[i]}
+		opts := []UpdateOption{UpdateOptionContent(content)}
+		if q.where != nil {
+			opts = append(opts, UpdateOptionWhere(q.where))
+		}
+		u := NewUpdate(doc.Id(), opts...)
+		statements = append(statements, fmt.Sprintf("IF array::len((%s)) = 0 { THROW %q }",
+			u, "conflict: "+doc.Id().String()))
+		// the where vars are shared by the updates, they are added once below
+		vars = append(vars, u.thing.valuedVars()...)
+		vars = append(vars, content)
+	}
+	statements = append(statements, "COMMIT TRANSACTION")
+	if q.where != nil {
+		vars = append(vars, q.where.valuedVars()...)
+	}
+	results, err := queryStatements(context.Background(), db, strings.Join(statements, "; "), vars)
+	if err != nil {
+		return err
+	}
+	var failed, notExecuted []ErrUpdateThing
+	for i, doc := range docs {
+		if i >= len(results) {
+			failed = append(failed, ErrUpdateThing{Thing: doc.Id(), Err: ErrNoResult})
+			continue
+		}
+		err := results[i].err(statements[i+1])
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrNotExecuted):
+			notExecuted = append(notExecuted, ErrUpdateThing{Thing: doc.Id(), Err: err})
+		default:
+			failed = append(failed, ErrUpdateThing{Thing: doc.Id(), Err: err})
+		}
+	}
+	// the statements not executed only tell that another one failed
+	if len(failed) == 0 {
+		failed = notExecuted
+	}
+	if len(failed) > 0 {
+		return ErrPartialUpdate{failed}
+	}
+	return nil
+}
+
+// statementResult is the result of one statement of a query
+type statementResult struct {
+	Result interface{} `json:"result"`
+	Status string      `json:"status"`
+	Detail string      `json:"detail"`
+	Time   string      `json:"time"`
+}
+
+// queryStatements runs the statements of sql and returns their results
+func queryStatements(ctx context.Context, db SurrealDriver, sql string, valued []conditionAtomVar) ([]statementResult, error) {
+
+	vars, err := valuate(valued)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := driverContext(db).QueryContext(ctx, sql, vars)
+	if err != nil {
+		return nil, fmt.Errorf("surrealdb: %w", err)
+	}
+
+	var results []statementResult
+
+	if err := surrealdb.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("surrealdb: unmarshal results: %w", err)
+	}
+
+	return results, nil
+
+}
+
+var (
+	ErrPageSize = errors.New("page size must be positive")
+	// ErrPagesOrder is the error of pages over a query which order is not
+	// stable, i.e. ORDER BY RAND(), GROUP BY or GROUP ALL
+	ErrPagesOrder = errors.New("pages need a stable order")
+)
+
+// DBPages iterates over the pages of a DBSelect
+//
+//	pages := SelectOn[D](q, db).Pages(100)
+//	for pages.Next() {
+//		for _, d := range pages.Page() {...}
+//	}
+//	if err := pages.Err(); err != nil {...}
+//
+// The pages stop at the LIMIT of the query if any; id is always the last
+// ORDER BY key so that records with equal keys are not missed nor repeated.
+// Queries ordered by RAND() or grouped fail with ErrPagesOrder.
+type DBPages[D any] struct {
+	query Select
+	db    SurrealDriver
+	size  int
+	start int
+	// end is the START+LIMIT of the query, 0 when the query has no limit
+	end int
+
+	page []D
+	done bool
+	err  error
+}
+
+func (q dbSelect[D]) Pages(size int) *DBPages[D] {
+	pages := &DBPages[D]{
+		query: q.query,
+		db:    q.db,
+		size:  size,
+		start: q.query.start,
+	}
+	if size <= 0 {
+		pages.err = ErrPageSize
+	}
+	if q.query.groupAll || len(q.query.groupBy) > 0 {
+		pages.err = ErrPagesOrder
+	}
+	if q.query.limit > 0 {
+		pages.end = q.query.start + q.query.limit
+	}
+	orderBy := make([]selectOrderBy, 0, len(q.query.orderBy)+1)
+	byId := false
+	for _, o := range q.query.orderBy {
+		if o.rand {
+			pages.err = ErrPagesOrder
+		}
+		byId = byId || o.field == Field("id")
+		orderBy = append(orderBy, o)
+	}
+	if !byId {
+		orderBy = append(orderBy, selectOrderBy{
+			field: Field("id"),
+			order: selectOrderAsc,
+		})
+	}
+	pages.query.orderBy = orderBy
+	return pages
+}
+
+// Next fetches the next page, it returns false when there are no more pages
+// or when an error occurred
+func (p *DBPages[D]) Next() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	size := p.size
+	if p.end > 0 {
+		if p.end-p.start <= 0 {
+			p.page, p.done = nil, true
+			return false
+		}
+		if p.end-p.start < size {
+			size = p.end - p.start
+		}
+	}
+	q := p.query
+	q.limit, q.start = size, p.start
+	docs, err := SelectOn[D](q, p.db).All().Do()
+	if err != nil {
+		p.page, p.err = nil, fmt.Errorf("page at %d: %w", p.start, err)
+		return false
+	}
+	if len(docs) == 0 {
+		p.page, p.done = nil, true
+		return false
+	}
+	p.page = docs
+	p.start += len(docs)
+	if len(docs) < size || (p.end > 0 && p.start >= p.end) {
+		p.done = true
+	}
+	return true
+}
+
+// Page returns the current page
+func (p *DBPages[D]) Page() []D {
+	return p.page
+}
+
+// Err returns the first error met while fetching pages
+//   - ErrPageSize
+//   - ErrPagesOrder
+//   - any error from DBSelect.All
+func (p *DBPages[D]) Err() error {
+	return p.err
 }
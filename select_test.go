@@ -1,7 +1,11 @@
 package surrealhigh
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,6 +75,34 @@ func TestDBSelect_Do(t *testing.T) {
 	})
 }
 
+type mockProjection struct {
+	RecordID int
+}
+
+func TestDBSelect_Do_projection(t *testing.T) {
+	t.Run("mock driver result decodes into projection", func(t *testing.T) {
+		q := NewQueryFrom(Table(""), QueryOptionFields(Field("RecordID")))
+		results, err := SelectOn[mockProjection](q, newMockDriver()).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []mockProjection{{RecordID: 0}}, results)
+	})
+}
+
+type mockAggregateRow struct {
+	N int `json:"RecordID"`
+}
+
+func TestDBSelect_Do_aggregate(t *testing.T) {
+	t.Run("mock driver result decodes into aggregate row", func(t *testing.T) {
+		q := NewQueryFrom(Table(""),
+			QueryOptionFields(NewProjectionAs(NewAggregateCount(), Field("RecordID"))),
+			QueryOptionGroupAll())
+		results, err := SelectOn[mockAggregateRow](q, newMockDriver()).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []mockAggregateRow{{N: 0}}, results)
+	})
+}
+
 func TestDBSelectAndUpdate_Do(t *testing.T) {
 	t.Run("mock driver update no error", func(t *testing.T) {
 		db := newMockDriver()
@@ -92,5 +124,439 @@ func (mock mockDriverResult) Create(thing string, data interface{}) (interface{}
 	}
 	return struct {
 		Id string `json:"id"`
-	}{id.String()}, nil
-}
\ No newline at end of file
+	}{id.Thing(tb).String()}, nil
+}
+
+// mockPagesDriver serves n docs honouring LIMIT and START
+type mockPagesDriver struct {
+	n       int
+	queries *[]string
+}
+
+func (driver mockPagesDriver) Driver() SurrealDB { return driver }
+
+var mockLimitStart = regexp.MustCompile(`LIMIT (\d+)(?: START (\d+))?`)
+
+func (driver mockPagesDriver) Query(sql string, vars interface{}) (interface{}, error) {
+	*driver.queries = append(*driver.queries, sql)
+	m := mockLimitStart.FindStringSubmatch(sql)
+	limit, _ := strconv.Atoi(m[1])
+	start, _ := strconv.Atoi(m[2])
+	var docs []mockDoc
+	for i := start; i < start+limit && i < driver.n; i++ {
+		docs = append(docs, mockDoc{RecordID: i})
+	}
+	return []interface{}{struct {
+		Result []mockDoc `json:"result"`
+		Status string    `json:"status"`
+	}{docs, "OK"}}, nil
+}
+
+func (driver mockPagesDriver) Update(what string, data interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (driver mockPagesDriver) Create(thing string, data interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestDBSelect_Pages(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		n, size int
+		opts    []QueryOption
+		pages   [][]int
+		queries []string
+	}{
+		{
+			name:  "5 docs by 2",
+			n:     5,
+			size:  2,
+			pages: [][]int{{0, 1}, {2, 3}, {4}},
+			queries: []string{
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 2",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 4",
+			},
+		},
+		{
+			name:  "4 docs by 2",
+			n:     4,
+			size:  2,
+			pages: [][]int{{0, 1}, {2, 3}},
+			queries: []string{
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 2",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 4",
+			},
+		},
+		{
+			name:    "no docs",
+			size:    2,
+			queries: []string{"SELECT * FROM  ORDER BY id ASC LIMIT 2"},
+		},
+		{
+			name:  "capped at limit",
+			n:     10,
+			size:  2,
+			opts:  []QueryOption{QueryOptionLimit(5), QueryOptionStart(1)},
+			pages: [][]int{{1, 2}, {3, 4}, {5}},
+			queries: []string{
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 1",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 2 START 3",
+				"SELECT * FROM  ORDER BY id ASC LIMIT 1 START 5",
+			},
+		},
+		{
+			name:  "id breaks ties",
+			n:     3,
+			size:  2,
+			opts:  []QueryOption{QueryOptionOrderByAsc(Field("tenant"))},
+			pages: [][]int{{0, 1}, {2}},
+			queries: []string{
+				"SELECT * FROM  ORDER BY tenant ASC, id ASC LIMIT 2",
+				"SELECT * FROM  ORDER BY tenant ASC, id ASC LIMIT 2 START 2",
+			},
+		},
+		{
+			name:    "ordered by id",
+			n:       1,
+			size:    2,
+			opts:    []QueryOption{QueryOptionOrderByDesc(Field("id"))},
+			pages:   [][]int{{0}},
+			queries: []string{"SELECT * FROM  ORDER BY id DESC LIMIT 2"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []string
+			db := mockPagesDriver{n: test.n, queries: &queries}
+			pages := SelectOn[mockDoc](NewQueryFrom(Table(""), test.opts...), db).Pages(test.size)
+			var got [][]int
+			for pages.Next() {
+				var page []int
+				for _, doc := range pages.Page() {
+					page = append(page, doc.RecordID)
+				}
+				got = append(got, page)
+			}
+			require.NoError(t, pages.Err())
+			assert.Equal(t, test.pages, got)
+			assert.Equal(t, test.queries, queries)
+		})
+	}
+	t.Run("bad page size", func(t *testing.T) {
+		pages := SelectOn[mockDoc](NewQueryFrom(Table("")), newMockDriver()).Pages(0)
+		assert.False(t, pages.Next())
+		assert.ErrorIs(t, pages.Err(), ErrPageSize)
+	})
+	for name, opt := range map[string]QueryOption{
+		"order by rand": QueryOptionOrderByRand(),
+		"group by":      QueryOptionGroupBy(Field("tenant")),
+		"group all":     QueryOptionGroupAll(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var queries []string
+			db := mockPagesDriver{n: 3, queries: &queries}
+			pages := SelectOn[mockDoc](NewQueryFrom(Table(""), opt), db).Pages(2)
+			assert.False(t, pages.Next())
+			assert.ErrorIs(t, pages.Err(), ErrPagesOrder)
+			assert.Empty(t, queries)
+		})
+	}
+}
+
+func TestDBSelect_cardinality(t *testing.T) {
+	docs := func(n int) mockQueryDriver {
+		result := []interface{}{}
+		for i := 0; i < n; i++ {
+			result = append(result, map[string]interface{}{"RecordID": i})
+		}
+		return newMockQueryDriver([]interface{}{map[string]interface{}{"result": result, "status": "OK"}})
+	}
+	q := NewQueryFrom(Table("mock"))
+	t.Run("one", func(t *testing.T) {
+		db := docs(1)
+		doc, err := SelectOn[mockDoc](q, db).One().Do()
+		require.NoError(t, err)
+		assert.Equal(t, mockDoc{RecordID: 0}, doc)
+		assert.Equal(t, "SELECT * FROM mock LIMIT 2", *db.sql)
+		_, err = SelectOn[mockDoc](q, docs(0)).One().Do()
+		assert.ErrorIs(t, err, ErrNoDoc)
+		_, err = SelectOn[mockDoc](q, docs(2)).One().Do()
+		assert.ErrorIs(t, err, ErrTooMany)
+	})
+	t.Run("first", func(t *testing.T) {
+		db := docs(1)
+		doc, err := SelectOn[mockDoc](NewQueryFrom(Table("mock"), QueryOptionLimit(10)), db).First().Do()
+		require.NoError(t, err)
+		assert.Equal(t, mockDoc{RecordID: 0}, doc)
+		assert.Equal(t, "SELECT * FROM mock LIMIT 1", *db.sql)
+		_, err = SelectOn[mockDoc](q, docs(0)).First().Do()
+		assert.ErrorIs(t, err, ErrNoDoc)
+	})
+	t.Run("maybe", func(t *testing.T) {
+		doc, err := SelectOn[mockDoc](q, docs(0)).Maybe().Do()
+		require.NoError(t, err)
+		assert.Nil(t, doc)
+		doc, err = SelectOn[mockDoc](q, docs(1)).Maybe().Do()
+		require.NoError(t, err)
+		assert.Equal(t, &mockDoc{RecordID: 0}, doc)
+		_, err = SelectOn[mockDoc](q, docs(2)).Maybe().Do()
+		assert.ErrorIs(t, err, ErrTooMany)
+	})
+	t.Run("all", func(t *testing.T) {
+		db := docs(0)
+		all, err := SelectOn[mockDoc](q, db).All().Do()
+		require.NoError(t, err)
+		assert.Empty(t, all)
+		assert.Equal(t, "SELECT * FROM mock", *db.sql)
+		all, err = SelectOn[mockDoc](q, docs(3)).All().Do()
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+	t.Run("no statement result", func(t *testing.T) {
+		_, err := SelectOn[mockDoc](q, newMockQueryDriver([]interface{}{})).All().Do()
+		assert.ErrorIs(t, err, ErrNoResult)
+	})
+}
+
+// mockQueryDriver records the last query and answers with results
+type mockQueryDriver struct {
+	sql     *string
+	vars    *interface{}
+	results interface{}
+}
+
+func newMockQueryDriver(results interface{}) mockQueryDriver {
+	return mockQueryDriver{sql: new(string), vars: new(interface{}), results: results}
+}
+
+func (driver mockQueryDriver) Driver() SurrealDB { return driver }
+
+func (driver mockQueryDriver) Query(sql string, vars interface{}) (interface{}, error) {
+	*driver.sql, *driver.vars = sql, vars
+	return driver.results, nil
+}
+
+func (driver mockQueryDriver) Update(what string, data interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (driver mockQueryDriver) Create(thing string, data interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// mockUpdateAllDriver selects docs, fails to update the things in fail and
+// answers any other query with statements
+type mockUpdateAllDriver struct {
+	docs       interface{}
+	fail       map[string]bool
+	statements []interface{}
+	updated    *[]string
+	sql        *string
+	vars       *interface{}
+}
+
+func newMockUpdateAllDriver(docs interface{}, fail map[string]bool, statements []interface{}) mockUpdateAllDriver {
+	return mockUpdateAllDriver{
+		docs:       docs,
+		fail:       fail,
+		statements: statements,
+		updated:    new([]string),
+		sql:        new(string),
+		vars:       new(interface{}),
+	}
+}
+
+func (driver mockUpdateAllDriver) Driver() SurrealDB { return driver }
+
+func (driver mockUpdateAllDriver) Query(sql string, vars interface{}) (interface{}, error) {
+	if strings.HasPrefix(sql, "SELECT") {
+		return []interface{}{map[string]interface{}{"result": driver.docs, "status": "OK"}}, nil
+	}
+	*driver.sql, *driver.vars = sql, vars
+	return driver.statements, nil
+}
+
+func (driver mockUpdateAllDriver) Update(what string, data interface{}) (interface{}, error) {
+	if driver.fail[what] {
+		return nil, errors.New("mock update failure")
+	}
+	*driver.updated = append(*driver.updated, what)
+	return nil, nil
+}
+
+func (driver mockUpdateAllDriver) Create(thing string, data interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// mockTxDriver is a mockUpdateAllDriver which runs the transactions of
+// updateInTransaction, the updates of the things in conflict match nothing
+// and throw; the things are committed only if no statement threw
+type mockTxDriver struct {
+	mockUpdateAllDriver
+	conflict  map[string]bool
+	committed *[]string
+}
+
+func newMockTxDriver(docs interface{}, conflict map[string]bool) mockTxDriver {
+	return mockTxDriver{
+		mockUpdateAllDriver: newMockUpdateAllDriver(docs, nil, nil),
+		conflict:            conflict,
+		committed:           new([]string),
+	}
+}
+
+func (driver mockTxDriver) Driver() SurrealDB { return driver }
+
+var mockTxUpdate = regexp.MustCompile(`^IF array::len\(\(UPDATE .*\)\) = 0 \{ THROW "(conflict: (\S+))" \}$`)
+
+func (driver mockTxDriver) Query(sql string, vars interface{}) (interface{}, error) {
+	if strings.HasPrefix(sql, "SELECT") {
+		return driver.mockUpdateAllDriver.Query(sql, vars)
+	}
+	*driver.sql, *driver.vars = sql, vars
+	var (
+		pending []string
+		thrown  = -1
+		detail  string
+	)
+	for _, statement := range strings.Split(sql, "; ") {
+		m := mockTxUpdate.FindStringSubmatch(statement)
+		if m == nil {
+			continue
+		}
+		if driver.conflict[m[2]] && thrown < 0 {
+			thrown, detail = len(pending), "An error occurred: "+m[1]
+		}
+		pending = append(pending, m[2])
+	}
+	results := make([]interface{}, len(pending))
+	for i := range pending {
+		switch {
+		case thrown < 0:
+			results[i] = map[string]interface{}{"result": nil, "status": "OK"}
+		case i == thrown:
+			results[i] = map[string]interface{}{"result": detail, "status": "ERR"}
+		default:
+			results[i] = map[string]interface{}{"result": "The query was not executed due to a failed transaction", "status": "ERR"}
+		}
+	}
+	if thrown < 0 {
+		*driver.committed = append(*driver.committed, pending...)
+	}
+	return results, nil
+}
+
+// withThingVars adds the variables binding the things to vars, see
+// thingExpression
+func withThingVars(vars map[string]interface{}, ths ...Thing) map[string]interface{} {
+	for _, th := range ths {
+		for _, v := range thingExpression(th).valuedVars() {
+			vars[string(v.name)] = v.value
+		}
+	}
+	return vars
+}
+
+type mockDocWithID mockDoc
+
+func (doc mockDocWithID) Table() Table { return "mock" }
+func (doc mockDocWithID) Id() Thing    { return Thing(fmt.Sprintf("mock:%d", doc.RecordID)) }
+
+func TestDBSelectAndUpdateAll_Do(t *testing.T) {
+	docs := []mockDoc{{RecordID: 0}, {RecordID: 1}, {RecordID: 2}}
+	out := func(doc mockDocWithID) mockDocWithID {
+		doc.IsOut = true
+		return doc
+	}
+	t.Run("update all", func(t *testing.T) {
+		db := newMockUpdateAllDriver(docs, nil, nil)
+		updated, err := SelectAndUpdateAll(NewQueryFrom(Table("mock")), out, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []mockDocWithID{{RecordID: 0, IsOut: true}, {RecordID: 1, IsOut: true}, {RecordID: 2, IsOut: true}}, updated)
+		assert.Equal(t, []string{"mock:0", "mock:1", "mock:2"}, *db.updated)
+	})
+	t.Run("partial update", func(t *testing.T) {
+		db := newMockUpdateAllDriver(docs, map[string]bool{"mock:1": true}, nil)
+		updated, err := SelectAndUpdateAll(NewQueryFrom(Table("mock")), out, db).Do()
+		var partial ErrPartialUpdate
+		require.ErrorAs(t, err, &partial)
+		require.Len(t, partial.Failed, 1)
+		assert.Equal(t, Thing("mock:1"), partial.Failed[0].Thing)
+		assert.Equal(t, []mockDocWithID{{RecordID: 0, IsOut: true}, {RecordID: 2, IsOut: true}}, updated)
+	})
+	t.Run("no doc", func(t *testing.T) {
+		db := newMockUpdateAllDriver([]mockDoc{}, nil, nil)
+		_, err := SelectAndUpdateAll(NewQueryFrom(Table("mock")), out, db).Do()
+		assert.ErrorIs(t, err, ErrNoDoc)
+	})
+	t.Run("transaction", func(t *testing.T) {
+		db := newMockTxDriver(docs, nil)
+		q := NewQueryFrom(Table("mock"), QueryOptionWhere(NewConditionIs(Field("is_out"), NewConditionAtomVar("out", false))))
+		updated, err := SelectAndUpdateAll(q, out, db, SelectAndUpdateOptionTransaction()).Do()
+		require.NoError(t, err)
+		assert.Len(t, updated, 3)
+		assert.Empty(t, *db.updated)
+		assert.Equal(t, []string{"mock:0", "mock:1", "mock:2"}, *db.committed)
+		assert.Equal(t, "BEGIN TRANSACTION; "+
+			`IF array::len((UPDATE `+thingExpression("mock:0").String()+` CONTENT $doc0 WHERE (is_out IS $out))) = 0 { THROW "conflict: mock:0" }; `+
+			`IF array::len((UPDATE `+thingExpression("mock:1").String()+` CONTENT $doc1 WHERE (is_out IS $out))) = 0 { THROW "conflict: mock:1" }; `+
+			`IF array::len((UPDATE `+thingExpression("mock:2").String()+` CONTENT $doc2 WHERE (is_out IS $out))) = 0 { THROW "conflict: mock:2" }; `+
+			"COMMIT TRANSACTION", *db.sql)
+		assert.Equal(t, withThingVars(map[string]interface{}{
+			"doc0": mockDocWithID{RecordID: 0, IsOut: true},
+			"doc1": mockDocWithID{RecordID: 1, IsOut: true},
+			"doc2": mockDocWithID{RecordID: 2, IsOut: true},
+			"out":  false,
+		}, "mock:0", "mock:1", "mock:2"), *db.vars)
+	})
+	t.Run("transaction conflict", func(t *testing.T) {
+		db := newMockTxDriver(docs, map[string]bool{"mock:1": true})
+		q := NewQueryFrom(Table("mock"), QueryOptionWhere(NewConditionIs(Field("is_out"), NewConditionAtomVar("out", false))))
+		updated, err := SelectAndUpdateAll(q, out, db, SelectAndUpdateOptionTransaction()).Do()
+		var partial ErrPartialUpdate
+		require.ErrorAs(t, err, &partial)
+		require.Len(t, partial.Failed, 1)
+		assert.Equal(t, Thing("mock:1"), partial.Failed[0].Thing)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, updated)
+		assert.Empty(t, *db.committed)
+		assert.Empty(t, *db.updated)
+	})
+}
+
+func TestDBSelectAndUpdate_Do_transaction(t *testing.T) {
+	docs := []mockDoc{{RecordID: 0}}
+	claim := func(doc mockDocWithID) mockDocWithID {
+		doc.IsOut = true
+		return doc
+	}
+	q := NewQueryFrom(Table("mock"), QueryOptionWhere(NewConditionIs(Field("is_out"), NewConditionAtomVar("out", false))))
+	t.Run("claimed", func(t *testing.T) {
+		db := newMockTxDriver(docs, nil)
+		doc, err := SelectAndUpdate(q, claim, db, SelectAndUpdateOptionTransaction()).Do()
+		require.NoError(t, err)
+		assert.Equal(t, mockDocWithID{RecordID: 0, IsOut: true}, doc)
+		assert.Equal(t, "BEGIN TRANSACTION; "+
+			`IF array::len((UPDATE `+thingExpression("mock:0").String()+` CONTENT $doc0 WHERE (is_out IS $out))) = 0 { THROW "conflict: mock:0" }; `+
+			"COMMIT TRANSACTION", *db.sql)
+		assert.Equal(t, []string{"mock:0"}, *db.committed)
+		assert.Empty(t, *db.updated)
+	})
+	t.Run("claimed concurrently", func(t *testing.T) {
+		db := newMockTxDriver(docs, map[string]bool{"mock:0": true})
+		_, err := SelectAndUpdate(q, claim, db, SelectAndUpdateOptionTransaction()).Do()
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Empty(t, *db.committed)
+	})
+	t.Run("transaction conflict", func(t *testing.T) {
+		ko := map[string]interface{}{
+			"result": "Failed to commit transaction due to a read or write conflict. This transaction can be retried",
+			"status": "ERR",
+		}
+		db := newMockUpdateAllDriver(docs, nil, []interface{}{ko})
+		_, err := SelectAndUpdate(q, claim, db, SelectAndUpdateOptionTransaction()).Do()
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
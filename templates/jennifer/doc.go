@@ -15,6 +15,7 @@ const origin = "github.com/4sp1/surrealhigh"
 type Doc struct {
 	table  sh.Table
 	fields []DocField
+	edge   bool
 
 	file *File
 }
@@ -30,12 +31,61 @@ type DocField struct {
 	qual  string
 	isptr bool
 	isarr bool
+	isver bool
 }
 
 func (f DocField) isTime() bool {
 	return f.t == "Time" && f.qual == "time"
 }
 
+// isInteger is true for the integer fields
+func (f DocField) isInteger() bool {
+	if f.qual != "" || f.isarr || f.isptr {
+		return false
+	}
+	switch f.t {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// isOrdered is true for the number and string fields
+func (f DocField) isOrdered() bool {
+	if f.isInteger() {
+		return true
+	}
+	if f.qual != "" || f.isarr || f.isptr {
+		return false
+	}
+	switch f.t {
+	case "string", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// elemType is the type of the field without array nor pointer, e.g. time.Time
+func (f DocField) elemType() *Statement {
+	if f.qual != "" {
+		return Qual(f.qual, f.t)
+	}
+	return Id(f.t)
+}
+
+// valueType is the type of the field in the public doc, e.g. []*time.Time
+func (f DocField) valueType() *Statement {
+	stmt := Null()
+	if f.isarr {
+		stmt = stmt.Index()
+	}
+	if f.isptr {
+		stmt = stmt.Op("*")
+	}
+	return stmt.Add(f.elemType())
+}
+
 type NewFieldOption func(DocField) DocField
 
 func NewFieldWithQual(qual string) NewFieldOption {
@@ -61,6 +111,15 @@ func NewFieldWithArray() NewFieldOption {
 	}
 }
 
+// NewFieldWithVersion marks the field as the doc version, see surrealhigh.VersionedDoc;
+// the field must be an integer and the only version field of the doc
+func NewFieldWithVersion() NewFieldOption {
+	return func(df DocField) DocField {
+		df.isver = true
+		return df
+	}
+}
+
 func NewField(name, t string, opts ...NewFieldOption) DocField {
 	f := DocField{Field: sh.Field(name), t: t}
 	log.Trace().Str("name", name).Str("t", t).Msg("NewField")
@@ -110,6 +169,15 @@ func (doc Doc) docStructFields() (codes []Code) {
 				Id(field.docStructFieldTypeId(doc)).
 				Tag(field.Tag()))
 	}
+	if doc.edge {
+		codes = append(codes,
+			Id("In").Id(doc.docEdgeType(edgeInField)).Tag(map[string]string{
+				"json": edgeInField + ",omitempty",
+			}),
+			Id("Out").Id(doc.docEdgeType(edgeOutField)).Tag(map[string]string{
+				"json": edgeOutField + ",omitempty",
+			}))
+	}
 	return append(codes,
 		Id("DocID").Id(doc.docIdType()).Tag(map[string]string{
 			"json": "id",
@@ -126,9 +194,29 @@ func (doc Doc) docIdType() string { // f${Table}_DocID
 	return "fDoc" + cc(doc.table.String()) + "_DocID"
 }
 
-func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
+const (
+	edgeInField  = "in"
+	edgeOutField = "out"
+)
+
+func (doc Doc) docEdgeType(field string) string { // fDoc${Table}_In, fDoc${Table}_Out
+	return "fDoc" + cc(doc.table.String()) + "_" + cc(field)
+}
+
+func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) Doc {
+	return newDoc(pkg, table, false, fields...)
+}
+
+// NewEdgeDoc is NewDoc for a graph edge doc, the doc struct carries the
+// in and out things of the edge
+func NewEdgeDoc(pkg sh.Package, table sh.Table, fields ...DocField) Doc {
+	return newDoc(pkg, table, true, fields...)
+}
+
+func newDoc(pkg sh.Package, table sh.Table, edge bool, fields ...DocField) (doc Doc) {
 
 	doc.table = table
+	doc.edge = edge
 	doc.fields = fields // DocId field not included and treated separate
 
 	// ## package
@@ -141,19 +229,8 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 
 	var pubDocFields []Code
 	for _, field := range fields {
-		stmt := Id(field.docStructFieldNameId())
-		if field.isarr {
-			stmt = stmt.Index()
-		}
-		if field.isptr {
-			stmt = stmt.Op("*")
-		}
-		if field.qual != "" {
-			stmt = stmt.Qual(field.qual, field.t)
-		} else {
-			stmt = stmt.Id(field.t)
-		}
-		pubDocFields = append(pubDocFields, stmt)
+		pubDocFields = append(pubDocFields,
+			Id(field.docStructFieldNameId()).Add(field.valueType()))
 	}
 	pubDocFields = append(pubDocFields,
 		Id("id").Qual(origin, "Id"),
@@ -239,6 +316,16 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 
 	f.Type().Id(doc.docIdType()).Qual(origin, "Id")
 
+	// ## edge in/out types surrealhigh.Thing
+	// type fDocA_In surrealhigh.Thing
+	// type fDocA_Out surrealhigh.Thing
+
+	if doc.edge {
+		for _, field := range []string{edgeInField, edgeOutField} {
+			f.Type().Id(doc.docEdgeType(field)).Qual(origin, "Thing")
+		}
+	}
+
 	// ## doc.Id() method
 	// func (doc docA) Id() surrealhigh.Thing { return surrealhigh.Id(doc.DocID).Thing(doc.Table()) }
 
@@ -278,6 +365,48 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 				Return(litField))
 	}
 
+	// ## fields typed predicates
+	// func (f fDocA_S) Eq(v string) surrealhigh.Condition {
+	// 	return surrealhigh.NewConditionEqual(f.Field(), surrealhigh.NewConditionValue(v))
+	// }
+
+	for _, field := range fields {
+		type predicate struct {
+			name, condition string
+			t               *Statement
+		}
+		predicates := []predicate{
+			{"Eq", "NewConditionEqual", field.valueType()},
+			{"Ne", "NewConditionNotEqual", field.valueType()},
+		}
+		switch {
+		case field.isTime() && !field.isarr:
+			predicates = append(predicates,
+				predicate{"Before", "NewConditionLessThan", field.elemType()},
+				predicate{"After", "NewConditionGreaterThan", field.elemType()})
+		case field.isOrdered():
+			predicates = append(predicates,
+				predicate{"Lt", "NewConditionLessThan", field.elemType()},
+				predicate{"Le", "NewConditionLessThanOrEqual", field.elemType()},
+				predicate{"Gt", "NewConditionGreaterThan", field.elemType()},
+				predicate{"Ge", "NewConditionGreaterThanOrEqual", field.elemType()})
+		case field.isarr && field.t != "byte":
+			predicates = append(predicates,
+				predicate{"Contains", "NewConditionContains", field.elemType()})
+		}
+		for _, p := range predicates {
+			f.Func().
+				Params(Id("f").Id(field.docStructFieldTypeId(doc))).
+				Id(p.name).
+				Params(Id("v").Add(p.t)).
+				Qual(origin, "Condition").
+				Block(
+					Return(Qual(origin, p.condition).Call(
+						Id("f").Dot("Field").Call(),
+						Qual(origin, "NewConditionValue").Call(Id("v")))))
+		}
+	}
+
 	// ## DocID Field() method
 	// func (_ fDocA_DocId) Field() surrealhigh.Field { return "id" }
 
@@ -289,6 +418,21 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 		Block(
 			Return(Lit(docIdField)))
 
+	// ## edge in/out Field() methods
+	// func (_ fDocA_In) Field() surrealhigh.Field { return "in" }
+
+	if doc.edge {
+		for _, field := range []string{edgeInField, edgeOutField} {
+			f.Func().
+				Params(Id("_").Id(doc.docEdgeType(field))).
+				Id("Field").
+				Params().
+				Qual(origin, "Field").
+				Block(
+					Return(Lit(field)))
+		}
+	}
+
 	// ## DocID Table() method
 	// func (_ fDocA_DocID) Table() surrealhigh.Table { return "a" }
 
@@ -303,6 +447,41 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 				Return(litTable))
 	}
 
+	// ## version methods
+	// func (doc docA) VersionField() surrealhigh.Field { return "v" }
+	// func (doc docA) DocVersion() int64 { return int64(doc.V) }
+	// func (doc docA) WithDocVersion(v int64) docA { doc.V = fDocA_V(v); return doc }
+
+	for _, field := range fields {
+		if !field.isver {
+			continue
+		}
+		f.Func().
+			Params(Id("doc").Id(doc.docStructId())).
+			Id("VersionField").
+			Params().
+			Qual(origin, "Field").
+			Block(
+				Return(Lit(field.Field.String())))
+		f.Func().
+			Params(Id("doc").Id(doc.docStructId())).
+			Id("DocVersion").
+			Params().
+			Int64().
+			Block(
+				Return(Int64().Parens(Id("doc").Dot(field.docStructFieldNameId()))))
+		f.Func().
+			Params(Id("doc").Id(doc.docStructId())).
+			Id("WithDocVersion").
+			Params(Id("v").Int64()).
+			Id(doc.docStructId()).
+			Block(
+				Id("doc").Dot(field.docStructFieldNameId()).Op("=").
+					Id(field.docStructFieldTypeId(doc)).Parens(Id("v")),
+				Return(Id("doc")))
+		break
+	}
+
 	// Times marshaler/unmarshaler TODO(malikbenkirane) read comments above
 	//	func (v f${Table}_${Field}) MarshalJSON() ([]byte, error) {
 	//  	return json.Marshal(time.Time(*v.t))
@@ -311,15 +490,19 @@ func NewDoc(pkg sh.Package, table sh.Table, fields ...DocField) (doc Doc) {
 	//  	return json.Umarshal(b, v.t)
 	// }
 	for t := range times {
-		stmt := Id("v").Dot("t")
+		stmt := Qual("time", "Time").Parens(Id("v").Dot("t"))
 		if t.isptr {
-			stmt = Op("*").Id("v").Dot("t")
+			stmt = Qual("time", "Time").Parens(Op("*").Id("v").Dot("t"))
+		}
+		// arrays of times are marshaled as they are
+		if t.isarr {
+			stmt = Id("v").Dot("t")
 		}
 		f.Func().Params(Id("v").Op("*").Id(t.docStructFieldTypeId(doc))).
 			Id("MarshalJSON").
 			Params().
 			Params(Index().Byte(), Error()).
-			Block(Return(Qual("encoding/json", "Marshal").Call(Qual("time", "Time").Parens(stmt))))
+			Block(Return(Qual("encoding/json", "Marshal").Call(stmt)))
 		stmt = Op("&").Id("v").Dot("t")
 		if t.isptr {
 			stmt = Id("v").Dot("t")
@@ -1,11 +1,13 @@
 package jennifer
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
 	"github.com/4sp1/surrealhigh"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDoc_Ids(t *testing.T) {
@@ -59,6 +61,14 @@ func TestDoc_Ids(t *testing.T) {
 				return Doc{table: table}.docIdType()
 			},
 		},
+		// fDoc${Table}_In
+		{
+			table: "test",
+			id:    "fDocTest_In",
+			fn: func(table surrealhigh.Table, field surrealhigh.Field, t string) string {
+				return Doc{table: table}.docEdgeType(edgeInField)
+			},
+		},
 		// ${Table}
 		{
 			table: "test",
@@ -82,4 +92,49 @@ func TestDoc_docStructFields(t *testing.T) {
 		}
 		assert.Equal(t, 3, len(Doc{fields: fields}.docStructFields()))
 	})
-}
\ No newline at end of file
+	t.Run("edge len+3", func(t *testing.T) {
+		fields := []DocField{NewField("a", "test")}
+		assert.Equal(t, 4, len(Doc{fields: fields, edge: true}.docStructFields()))
+	})
+}
+func TestDocField_valueType(t *testing.T) {
+	for _, test := range []struct {
+		field DocField
+		t     string
+	}{
+		{field: NewField("s", "string"), t: "string"},
+		{field: NewField("t", "Time", NewFieldWithQual("time"), NewFieldWithPointer()), t: "*time.Time"},
+		{field: NewField("l", "string", NewFieldWithArray()), t: "[]string"},
+	} {
+		t.Run(test.t, func(t *testing.T) {
+			assert.Equal(t, test.t, fmt.Sprintf("%#v", test.field.valueType()))
+		})
+	}
+}
+
+func TestNewDoc_predicates(t *testing.T) {
+	b := bytes.Buffer{}
+	doc := NewDoc("gold", "a",
+		NewField("s", "string"),
+		NewField("t", "Time", NewFieldWithQual("time")),
+		NewField("l", "string", NewFieldWithArray()),
+		NewField("b", "byte", NewFieldWithArray()),
+		NewField("ts", "Time", NewFieldWithQual("time"), NewFieldWithArray()))
+	require.NoError(t, doc.Write(&b))
+	for _, predicate := range []string{
+		"func (f fDocA_S) Eq(v string) surrealhigh.Condition {",
+		"func (f fDocA_S) Ge(v string) surrealhigh.Condition {",
+		"return surrealhigh.NewConditionGreaterThanOrEqual(f.Field(), surrealhigh.NewConditionValue(v))",
+		"func (f fDocA_T) After(v time.Time) surrealhigh.Condition {",
+		"func (f fDocA_L) Contains(v string) surrealhigh.Condition {",
+		"func (f fDocA_B) Ne(v []byte) surrealhigh.Condition {",
+		"func (f fDocA_Ts) Contains(v time.Time) surrealhigh.Condition {",
+		"func (f fDocA_Ts) Eq(v []time.Time) surrealhigh.Condition {",
+	} {
+		assert.Contains(t, b.String(), predicate)
+	}
+	assert.NotContains(t, b.String(), "func (f fDocA_T) Lt(")
+	assert.NotContains(t, b.String(), "func (f fDocA_B) Contains(")
+	assert.NotContains(t, b.String(), "func (f fDocA_Ts) Before(")
+	assert.NotContains(t, b.String(), "func (f fDocA_Ts) After(")
+}
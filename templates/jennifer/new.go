@@ -1,12 +1,15 @@
 package jennifer
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"io"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/4sp1/surrealhigh"
@@ -14,8 +17,26 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
-func NewGen(args, tags, docs []string, pkg, out string) error {
+type GenOption func(Generator) Generator
+
+// GenOptionEdges marks the given doc struct names as graph edges
+func GenOptionEdges(edges ...string) GenOption {
+	return func(g Generator) Generator {
+		if g.edges == nil {
+			g.edges = make(map[string]struct{})
+		}
+		for _, edge := range edges {
+			g.edges[edge] = struct{}{}
+		}
+		return g
+	}
+}
+
+func NewGen(args, tags, docs []string, pkg, out string, opts ...GenOption) error {
 	g := Generator{out: os.Stdout}
+	for _, opt := range opts {
+		g = opt(g)
+	}
 	if len(out) > 0 {
 		f, err := os.Create(out)
 		if err != nil {
@@ -37,10 +58,18 @@ func NewGen(args, tags, docs []string, pkg, out string) error {
 					}
 				}
 				fmt.Println(v)
-				if err := NewDoc(
+				newDoc := NewDoc
+				if _, ok := g.edges[docName]; ok {
+					newDoc = NewEdgeDoc
+				}
+				fields, err := v.docFields()
+				if err != nil {
+					return err
+				}
+				if err := newDoc(
 					surrealhigh.Package(pkg),
 					surrealhigh.Table(strings.ToLower(v.structName)),
-					v.docFields()...).Write(g.out); err != nil {
+					fields...).Write(g.out); err != nil {
 					return err
 				}
 			}
@@ -50,8 +79,9 @@ func NewGen(args, tags, docs []string, pkg, out string) error {
 }
 
 type Generator struct {
-	pkg *Package
-	out io.Writer
+	pkg   *Package
+	out   io.Writer
+	edges map[string]struct{}
 }
 
 type Package struct {
@@ -89,7 +119,15 @@ func (v Value) String() string {
 	return fmt.Sprintf("%s %s", v.structName, strings.Join(fields, " "))
 }
 
-func (v Value) docFields() (fields []DocField) {
+var (
+	ErrVersionType   = errors.New("version field is not an integer")
+	ErrVersionFields = errors.New("more than one version field")
+)
+
+// docFields returns the fields of the doc, it fails with ErrVersionType or
+// ErrVersionFields when the version tag is misused
+func (v Value) docFields() (fields []DocField, err error) {
+	var version string
 	for _, field := range v.fields {
 
 		field.generateTypeIdents() // prepare fieldIdent, typeQual, and isPointer
@@ -105,6 +143,9 @@ func (v Value) docFields() (fields []DocField) {
 		if field.isArray {
 			opts = append(opts, NewFieldWithArray())
 		}
+		if field.isVersion {
+			opts = append(opts, NewFieldWithVersion())
+		}
 
 		log.Trace().
 			Str("fieldName", field.fieldName).
@@ -113,10 +154,22 @@ func (v Value) docFields() (fields []DocField) {
 			Bool("isptr", field.isPointer).
 			Msg("docFields")
 
-		fields = append(fields, NewField(field.fieldName, field.typeIdent, opts...))
+		docField := NewField(field.fieldName, field.typeIdent, opts...)
+		if docField.isver {
+			if !docField.isInteger() {
+				return nil, fmt.Errorf("%s.%s: %w", v.structName, field.fieldName, ErrVersionType)
+			}
+			if version != "" {
+				return nil, fmt.Errorf("%s.%s and %s.%s: %w",
+					v.structName, version, v.structName, field.fieldName, ErrVersionFields)
+			}
+			version = field.fieldName
+		}
+
+		fields = append(fields, docField)
 
 	}
-	return
+	return fields, nil
 }
 
 type Field struct {
@@ -128,6 +181,7 @@ type Field struct {
 	typeIdent string
 	isPointer bool
 	isArray   bool
+	isVersion bool
 }
 
 func (f Field) String() string {
@@ -207,6 +261,7 @@ func (f *FileGen) walk(node ast.Node) bool {
 				fields = append(fields, Field{
 					fieldName: name.Name,
 					typeExpr:  field.Type,
+					isVersion: isVersionField(field),
 				})
 			}
 		}
@@ -222,6 +277,18 @@ func (f *FileGen) walk(node ast.Node) bool {
 	return false
 }
 
+// isVersionField reports whether the field is tagged `surrealhigh:"version"`
+func isVersionField(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	return reflect.StructTag(tag).Get("surrealhigh") == "version"
+}
+
 // parsePackage analyzes the single package constructed from the patterns and tags.
 // parsePackage exits if there is an error.
 func (g *Generator) parsePackage(patterns []string, tags []string) {
@@ -0,0 +1,57 @@
+package jennifer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVersionField(t *testing.T) {
+	src := "package model\n" +
+		"type a struct {\n" +
+		"\tv int `surrealhigh:\"version\"`\n" +
+		"\tw int `json:\"w\"`\n" +
+		"\ts string\n" +
+		"}\n"
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", src, 0)
+	require.NoError(t, err)
+	fg := FileGen{file: file, structName: "a"}
+	ast.Inspect(file, fg.walk)
+	require.Len(t, fg.values, 1)
+	var versions []string
+	for _, f := range fg.values[0].fields {
+		if f.isVersion {
+			versions = append(versions, f.fieldName)
+		}
+	}
+	assert.Equal(t, []string{"v"}, versions)
+}
+
+func TestValue_docFields_version(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		fields string
+		err    error
+	}{
+		{name: "int64", fields: "\tv int64 `surrealhigh:\"version\"`\n"},
+		{name: "string", fields: "\tv string `surrealhigh:\"version\"`\n", err: ErrVersionType},
+		{name: "time", fields: "\tv time.Time `surrealhigh:\"version\"`\n", err: ErrVersionType},
+		{name: "pointer", fields: "\tv *int `surrealhigh:\"version\"`\n", err: ErrVersionType},
+		{name: "two", fields: "\tv int `surrealhigh:\"version\"`\n\tw int `surrealhigh:\"version\"`\n", err: ErrVersionFields},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			src := "package model\nimport \"time\"\nvar _ time.Time\ntype a struct {\n" + test.fields + "}\n"
+			file, err := parser.ParseFile(token.NewFileSet(), "model.go", src, 0)
+			require.NoError(t, err)
+			fg := FileGen{file: file, structName: "a"}
+			ast.Inspect(file, fg.walk)
+			require.Len(t, fg.values, 1)
+			_, err = fg.values[0].docFields()
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package surrealhigh
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Statement is a statement built with NewQueryFrom, NewCreate, NewUpdate,
+// NewDeleteFrom, Relate or NewLet
+type Statement interface {
+	fmt.Stringer
+	valuedVars() []conditionAtomVar
+}
+
+var (
+	_ Statement = Select{}
+	_ Statement = Create{}
+	_ Statement = Update{}
+	_ Statement = Delete{}
+	_ Statement = Relation[Doc]{}
+)
+
+// Tx collects statements and runs them in a single transaction
+//
+//	tx := NewTx(db)
+//	created := tx.Add(NewCreate(doc))
+//	selected := tx.Add(NewQueryFrom(doc.Table()))
+//	results, err := tx.Commit()
+//	docs, err := ResultOf[D](results, selected)
+//
+// The statements share their variables, a name used twice fails with
+// ErrDuplicateValuation.
+type Tx struct {
+	db         SurrealDriver
+	statements []Statement
+}
+
+func NewTx(db SurrealDriver) *Tx {
+	return &Tx{db: db}
+}
+
+// Add appends the statement s to the transaction
+func (tx *Tx) Add(s Statement) Slot {
+	tx.statements = append(tx.statements, s)
+	return Slot(len(tx.statements) - 1)
+}
+
+// Commit runs the statements within BEGIN TRANSACTION and COMMIT TRANSACTION,
+// it returns with the following errors
+//   - any error from Batch.Run
+//   - the error of the failing statement, the server then cancels the
+//     transaction and reports the other statements with ErrNotExecuted
+func (tx *Tx) Commit() (Results, error) {
+	results, err := runStatements(tx.db, tx.String(), tx.statements)
+	if err != nil {
+		return nil, fmt.Errorf("transaction: %w", err)
+	}
+	// the statements not executed only tell that another one failed
+	var notExecuted error
+	for i, r := range results {
+		switch {
+		case r.Err == nil:
+		case errors.Is(r.Err, ErrNotExecuted):
+			if notExecuted == nil {
+				notExecuted = fmt.Errorf("transaction: statement %d: %w", i, r.Err)
+			}
+		default:
+			return results, fmt.Errorf("transaction: statement %d: %w", i, r.Err)
+		}
+	}
+	return results, notExecuted
+}
+
+func (tx *Tx) String() string {
+	if len(tx.statements) == 0 {
+		return "BEGIN TRANSACTION; COMMIT TRANSACTION"
+	}
+	return "BEGIN TRANSACTION; " + joinStatements(tx.statements) + "; COMMIT TRANSACTION"
+}
@@ -0,0 +1,78 @@
+package surrealhigh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTx(t *testing.T) {
+	newTx := func(db SurrealDriver) (*Tx, Slot, Slot) {
+		tx := NewTx(db)
+		updated := tx.Add(NewUpdate(Table("records"),
+			UpdateOptionSet(Field("is_out"), NewConditionAtomVar("out", true)),
+			UpdateOptionReturn(ReturnNone)))
+		selected := tx.Add(NewQueryFrom(Table("records"),
+			QueryOptionWhere(NewConditionIs(Field("record_id"), NewConditionAtomVar("rid", 1)))))
+		return tx, updated, selected
+	}
+	t.Run("commit", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{
+			map[string]interface{}{"result": []interface{}{}, "status": "OK"},
+			map[string]interface{}{"result": []interface{}{map[string]interface{}{"RecordID": 1, "IsOut": true}}, "status": "OK"},
+		})
+		tx, updated, selected := newTx(db)
+		results, err := tx.Commit()
+		require.NoError(t, err)
+		assert.Equal(t, "BEGIN TRANSACTION; "+
+			"UPDATE records SET is_out = $out RETURN NONE; "+
+			"SELECT * FROM records WHERE (record_id IS $rid); "+
+			"COMMIT TRANSACTION", *db.sql)
+		assert.Equal(t, map[string]interface{}{"out": true, "rid": 1}, *db.vars)
+		docs, err := ResultOf[mockDoc](results, selected)
+		require.NoError(t, err)
+		assert.Equal(t, []mockDoc{{RecordID: 1, IsOut: true}}, docs)
+		docs, err = ResultOf[mockDoc](results, updated)
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+		_, err = ResultOf[mockDoc](results, Slot(2))
+		assert.ErrorIs(t, err, ErrNoResult)
+	})
+	t.Run("failed statement", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{
+			map[string]interface{}{"result": "boom", "status": "ERR"},
+			map[string]interface{}{"result": "The query was not executed due to a failed transaction", "status": "ERR"},
+		})
+		tx, _, selected := newTx(db)
+		results, err := tx.Commit()
+		assert.ErrorContains(t, err, "statement 0")
+		assert.ErrorContains(t, err, "boom")
+		_, err = ResultOf[mockDoc](results, selected)
+		assert.ErrorContains(t, err, "failed transaction")
+	})
+	t.Run("failed statement after not executed ones", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{
+			map[string]interface{}{"result": "The query was not executed due to a failed transaction", "status": "ERR"},
+			map[string]interface{}{"result": "boom", "status": "ERR"},
+		})
+		tx, _, _ := newTx(db)
+		_, err := tx.Commit()
+		assert.ErrorContains(t, err, "statement 1")
+		assert.ErrorContains(t, err, "boom")
+		assert.NotErrorIs(t, err, ErrNotExecuted)
+	})
+	t.Run("creates share no variable", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{})
+		tx := NewTx(db)
+		tx.Add(NewCreate(mockEdge{At: 1}))
+		tx.Add(NewCreate(mockEdge{At: 2}))
+		tx.Add(NewCreate(mockEdge{At: 1}))
+		tx.Add(Relate(Thing("person:a"), Table("likes"), Thing("post:b"), mockEdge{At: 1}))
+		tx.Add(Relate(Thing("person:a"), Table("likes"), Thing("post:c"), mockEdge{At: 3}))
+		_, err := tx.Commit()
+		require.NoError(t, err)
+		// 3 contents, the tables person and post and the ids a, b and c
+		assert.Len(t, *db.vars, 8)
+	})
+}
@@ -0,0 +1,223 @@
+package surrealhigh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewUpdate updates all the records of a table or a single record, a record
+// is bound as type::thing($tb, $id)
+func NewUpdate[T Table | Thing](what T, opts ...UpdateOption) Update {
+	u := Update{what: string(what)}
+	if th, ok := any(what).(Thing); ok {
+		u.thing = thingExpression(th)
+	}
+	for _, opt := range opts {
+		u = opt(u)
+	}
+	return u
+}
+
+type UpdateOption func(Update) Update
+
+// UpdateOptionSet renders SET f = v, it discards MERGE, PATCH and CONTENT
+func UpdateOptionSet(f Field, v ConditionAtom) UpdateOption {
+	return updateOptionSet(f, updateOpSet, v)
+}
+
+// UpdateOptionAdd renders SET f += v which increments numbers and appends to
+// arrays
+func UpdateOptionAdd(f Field, v ConditionAtom) UpdateOption {
+	return updateOptionSet(f, updateOpAdd, v)
+}
+
+// UpdateOptionRemove renders SET f -= v which decrements numbers and removes
+// from arrays
+func UpdateOptionRemove(f Field, v ConditionAtom) UpdateOption {
+	return updateOptionSet(f, updateOpRemove, v)
+}
+
+func updateOptionSet(f Field, op updateOp, v ConditionAtom) UpdateOption {
+	return func(u Update) Update {
+		u.set = append(append([]updateAssignment{}, u.set...), updateAssignment{
+			field: f,
+			op:    op,
+			value: v,
+		})
+		u.data = nil
+		return u
+	}
+}
+
+// UpdateOptionMerge renders MERGE v, it discards SET, PATCH and CONTENT
+func UpdateOptionMerge(v ConditionAtom) UpdateOption {
+	return updateOptionData(updateDataMerge, v)
+}
+
+// UpdateOptionPatch renders PATCH v where v is a JSON patch, it discards SET,
+// MERGE and CONTENT
+func UpdateOptionPatch(v ConditionAtom) UpdateOption {
+	return updateOptionData(updateDataPatch, v)
+}
+
+// UpdateOptionContent renders CONTENT v which replaces the whole records, it
+// discards SET, MERGE and PATCH
+func UpdateOptionContent(v ConditionAtom) UpdateOption {
+	return updateOptionData(updateDataContent, v)
+}
+
+func updateOptionData(kind updateDataKind, v ConditionAtom) UpdateOption {
+	return func(u Update) Update {
+		u.set = nil
+		u.data = &updateData{kind: kind, value: v}
+		return u
+	}
+}
+
+func UpdateOptionWhere(c Condition) UpdateOption {
+	return func(u Update) Update {
+		u.where = c
+		return u
+	}
+}
+
+func UpdateOptionReturn(r Return) UpdateOption {
+	return func(u Update) Update {
+		u.ret = r
+		return u
+	}
+}
+
+// UpdateOptionTimeout stops the statement on the server after timeout (TIMEOUT)
+func UpdateOptionTimeout(timeout time.Duration) UpdateOption {
+	return func(u Update) Update {
+		u.timeout = timeout
+		return u
+	}
+}
+
+// UpdateOptionParallel processes records in parallel on the server (PARALLEL)
+func UpdateOptionParallel() UpdateOption {
+	return func(u Update) Update {
+		u.parallel = true
+		return u
+	}
+}
+
+// Update is an UPDATE statement
+type Update struct {
+	what  string
+	thing Expression
+	set   []updateAssignment
+	data  *updateData
+	where valuedWhereClause
+	ret   Return
+
+	statementOptions
+}
+
+type updateOp string
+
+const (
+	updateOpSet    = updateOp("=")
+	updateOpAdd    = updateOp("+=")
+	updateOpRemove = updateOp("-=")
+)
+
+type updateAssignment struct {
+	field Field
+	op    updateOp
+	value valuedWhereClause
+}
+
+type updateDataKind string
+
+const (
+	updateDataMerge   = updateDataKind("MERGE")
+	updateDataPatch   = updateDataKind("PATCH")
+	updateDataContent = updateDataKind("CONTENT")
+)
+
+type updateData struct {
+	kind  updateDataKind
+	value valuedWhereClause
+}
+
+func (u Update) String() string {
+	b := strings.Builder{}
+	b.WriteString("UPDATE ")
+	if u.thing != nil {
+		b.WriteString(u.thing.String())
+	} else {
+		b.WriteString(u.what)
+	}
+	for i, a := range u.set {
+		if i == 0 {
+			b.WriteString(" SET ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(fmt.Sprintf("%s %s %s", a.field, a.op, a.value.String()))
+	}
+	if u.data != nil {
+		b.WriteString(" ")
+		b.WriteString(string(u.data.kind))
+		b.WriteString(" ")
+		b.WriteString(u.data.value.String())
+	}
+	if u.where != nil {
+		b.WriteString(" WHERE ")
+		b.WriteString(u.where.String())
+	}
+	if u.ret != "" {
+		b.WriteString(" RETURN ")
+		b.WriteString(string(u.ret))
+	}
+	b.WriteString(u.statementOptions.String())
+	return b.String()
+}
+
+func (u Update) valuedVars() (vars []conditionAtomVar) {
+	if u.thing != nil {
+		vars = append(vars, u.thing.valuedVars()...)
+	}
+	for _, a := range u.set {
+		vars = append(vars, a.value.valuedVars()...)
+	}
+	if u.data != nil {
+		vars = append(vars, u.data.value.valuedVars()...)
+	}
+	if u.where != nil {
+		vars = append(vars, u.where.valuedVars()...)
+	}
+	return vars
+}
+
+type DBUpdate[D any] interface {
+	// Do returns the updated docs as returned by the RETURN clause, AFTER by
+	// default, there is no doc when nothing matched or with RETURN NONE; it
+	// returns with the errors of DBSelect.All
+	Do() ([]D, error)
+}
+
+func UpdateOn[D any](u Update, db SurrealDriver) DBUpdate[D] {
+	return DBUpdate[D](dbUpdate[D]{
+		update: u,
+		db:     db,
+	})
+}
+
+type dbUpdate[D any] struct {
+	update Update
+	db     SurrealDriver
+}
+
+func (u dbUpdate[D]) Do() ([]D, error) {
+	docs, err := queryAll[D](context.Background(), u.db, u.update.String(), u.update.valuedVars())
+	if err != nil {
+		return nil, fmt.Errorf("update %q: %w", u.update.what, err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,125 @@
+package surrealhigh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdate(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		update Update
+		sql    string
+		vars   []conditionAtomVar
+	}{
+		{
+			name: "set where",
+			update: NewUpdate(Table("records"),
+				UpdateOptionSet(Field("is_out"), NewConditionAtomVar("out", true)),
+				UpdateOptionAdd(Field("n"), NewConditionAtomVar("n", 1)),
+				UpdateOptionRemove(Field("tags"), NewConditionAtomVar("tag", "new")),
+				UpdateOptionWhere(NewConditionIs(Field("record_id"), NewConditionAtomVar("rid", 2))),
+			),
+			sql: "UPDATE records SET is_out = $out, n += $n, tags -= $tag WHERE (record_id IS $rid)",
+			vars: []conditionAtomVar{
+				{name: "out", value: true},
+				{name: "n", value: 1},
+				{name: "tag", value: "new"},
+				{name: "rid", value: 2},
+			},
+		},
+		{
+			name:   "merge thing return diff",
+			update: NewUpdate(Thing("records:a"), UpdateOptionMerge(NewConditionAtomVar("m", nil)), UpdateOptionReturn(ReturnDiff)),
+			sql:    "UPDATE type::thing($v_e9cbcd033f3945fd, $v_d4272417d7c77eea) MERGE $m RETURN DIFF",
+			vars: []conditionAtomVar{
+				{name: "v_e9cbcd033f3945fd", value: "records", encoded: []byte(`"records"`)},
+				{name: "v_d4272417d7c77eea", value: "a", encoded: []byte(`"a"`)},
+				{name: "m"},
+			},
+		},
+		{
+			name:   "patch",
+			update: NewUpdate(Thing("records:a"), UpdateOptionPatch(NewConditionAtomVar("p", nil))),
+			sql:    "UPDATE type::thing($v_e9cbcd033f3945fd, $v_d4272417d7c77eea) PATCH $p",
+			vars: []conditionAtomVar{
+				{name: "v_e9cbcd033f3945fd", value: "records", encoded: []byte(`"records"`)},
+				{name: "v_d4272417d7c77eea", value: "a", encoded: []byte(`"a"`)},
+				{name: "p"},
+			},
+		},
+		{
+			name:   "merge return none timeout",
+			update: NewUpdate(Table("records"), UpdateOptionMerge(NewConditionAtomVar("m", nil)), UpdateOptionReturn(ReturnNone), UpdateOptionTimeout(2*time.Minute)),
+			sql:    "UPDATE records MERGE $m RETURN NONE TIMEOUT 2m",
+			vars:   []conditionAtomVar{{name: "m"}},
+		},
+		{
+			name: "content discards set",
+			update: NewUpdate(Thing("records:a"),
+				UpdateOptionSet(Field("is_out"), NewConditionAtomVar("out", true)),
+				UpdateOptionContent(NewConditionAtomVar("c", nil)),
+				UpdateOptionReturn(ReturnNone)),
+			sql: "UPDATE type::thing($v_e9cbcd033f3945fd, $v_d4272417d7c77eea) CONTENT $c RETURN NONE",
+			vars: []conditionAtomVar{
+				{name: "v_e9cbcd033f3945fd", value: "records", encoded: []byte(`"records"`)},
+				{name: "v_d4272417d7c77eea", value: "a", encoded: []byte(`"a"`)},
+				{name: "c"},
+			},
+		},
+		{
+			name: "set discards merge",
+			update: NewUpdate(Table("records"),
+				UpdateOptionMerge(NewConditionAtomVar("m", nil)),
+				UpdateOptionSet(Field("is_out"), NewConditionAtomVar("out", true))),
+			sql:  "UPDATE records SET is_out = $out",
+			vars: []conditionAtomVar{{name: "out", value: true}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sql, test.update.String())
+			assert.Equal(t, test.vars, test.update.valuedVars())
+		})
+	}
+}
+
+func TestDBUpdate_Do(t *testing.T) {
+	t.Run("bulk update", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{
+			"result": []interface{}{
+				map[string]interface{}{"RecordID": 1, "IsOut": true},
+				map[string]interface{}{"RecordID": 2, "IsOut": true},
+			},
+			"status": "OK",
+		}})
+		u := NewUpdate(Table("records"),
+			UpdateOptionSet(Field("is_out"), NewConditionAtomVar("out", true)),
+			UpdateOptionWhere(NewConditionIs(Field("is_out"), NewConditionAtomVar("in", false))))
+		docs, err := UpdateOn[mockDoc](u, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []mockDoc{{RecordID: 1, IsOut: true}, {RecordID: 2, IsOut: true}}, docs)
+		assert.Equal(t, "UPDATE records SET is_out = $out WHERE (is_out IS $in)", *db.sql)
+		assert.Equal(t, map[string]interface{}{"out": true, "in": false}, *db.vars)
+	})
+	t.Run("no match", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		docs, err := UpdateOn[mockDoc](NewUpdate(Table("records")), db).Do()
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+	t.Run("no statement result", func(t *testing.T) {
+		_, err := UpdateOn[mockDoc](NewUpdate(Table("records")), newMockQueryDriver([]interface{}{})).Do()
+		assert.ErrorIs(t, err, ErrNoResult)
+	})
+	t.Run("thing injection", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		u := NewUpdate(Thing("records:a SET admin = true; DELETE person"), UpdateOptionMerge(NewConditionAtomVar("m", nil)))
+		_, err := UpdateOn[mockDoc](u, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE type::thing($v_e9cbcd033f3945fd, "+NewConditionValue("a SET admin = true; DELETE person").String()+") MERGE $m", *db.sql)
+		assert.Equal(t, withThingVars(map[string]interface{}{"m": nil}, "records:a SET admin = true; DELETE person"), *db.vars)
+	})
+}
@@ -0,0 +1,107 @@
+package surrealhigh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// VersionedDoc is a doc with a version field used for optimistic concurrency
+// control, sh-gen-types generates it for a field tagged `surrealhigh:"version"`
+type VersionedDoc[D any] interface {
+	DocWithID
+	VersionField() Field
+	DocVersion() int64
+	WithDocVersion(int64) D
+}
+
+// ErrVersionConflict is an ErrConflict met when the version of a doc changed
+// since it was read
+var ErrVersionConflict = fmt.Errorf("version %w", ErrConflict)
+
+type DBSwap[D any] interface {
+	// Do returns the written doc with the following errors
+	// - type ErrDuplicateValuation
+	// - any error from surrealdb.go query driver
+	// - any error from surrealdb.go unmarshal
+	// - type ErrStatement
+	// - ErrNoResult
+	// - ErrVersionConflict
+	Do() (D, error)
+}
+
+// SwapOn writes doc with an incremented version only if the stored version is
+// still the version of doc
+//
+//	UPDATE th CONTENT $doc WHERE (version = $version)
+func SwapOn[D VersionedDoc[D]](doc D, db SurrealDriver) DBSwap[D] {
+	return DBSwap[D](dbSwap[D]{
+		doc: doc,
+		db:  db,
+	})
+}
+
+type dbSwap[D VersionedDoc[D]] struct {
+	doc D
+	db  SurrealDriver
+}
+
+func (s dbSwap[D]) Do() (D, error) {
+	newDoc := s.doc.WithDocVersion(s.doc.DocVersion() + 1)
+	u := NewUpdate(s.doc.Id(),
+		UpdateOptionContent(NewConditionAtomVar("doc", newDoc)),
+		UpdateOptionWhere(NewConditionEqual(s.doc.VersionField(), NewConditionAtomVar("version", s.doc.DocVersion()))))
+	results, err := queryStatements(context.Background(), s.db, u.String(), u.valuedVars())
+	if err != nil {
+		return newDoc, fmt.Errorf("swap %q: %w", s.doc.Id(), err)
+	}
+	if len(results) == 0 {
+		return newDoc, fmt.Errorf("swap %q: %w", s.doc.Id(), ErrNoResult)
+	}
+	docs, err := DecodeResult[D](results[0].asStatementResult(u.String()))
+	if err != nil {
+		return newDoc, fmt.Errorf("swap %q: %w", s.doc.Id(), err)
+	}
+	if len(docs) == 0 {
+		return newDoc, fmt.Errorf("swap %q: %w", s.doc.Id(), ErrVersionConflict)
+	}
+	return docs[0], nil
+}
+
+// SelectAndSwap is SelectAndUpdate in compare-and-swap mode, the first doc
+// matched is updated with SwapOn
+func SelectAndSwap[D VersionedDoc[D]](q Select, update func(D) D, db SurrealDriver) DBSelectAndUpdate[D] {
+	return DBSelectAndUpdate[D](dbSelectSwap[D]{
+		query:  q,
+		update: update,
+		db:     db,
+	})
+}
+
+type dbSelectSwap[D VersionedDoc[D]] struct {
+	query  Select
+	update func(D) D
+	db     SurrealDriver
+}
+
+func (u dbSelectSwap[D]) Do() (D, error) {
+	doc, err := SelectOn[D](u.query, u.db).First().Do()
+	if err != nil {
+		return doc, fmt.Errorf("select on %q: %w", doc.Table(), err)
+	}
+	// the version read is the one to compare with whatever update does
+	newDoc := u.update(doc).WithDocVersion(doc.DocVersion())
+	return SwapOn(newDoc, u.db).Do()
+}
+
+// RetryOnConflict calls f until it does not fail with ErrConflict, it gives
+// up after attempts calls and returns the last error; f is called at least
+// once even when attempts is not positive
+func RetryOnConflict(attempts int, f func() error) (err error) {
+	for i := 0; i == 0 || i < attempts; i++ {
+		if err = f(); !errors.Is(err, ErrConflict) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package surrealhigh
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockVersionedDoc struct {
+	RecordID int   `json:"record_id"`
+	V        int64 `json:"v"`
+}
+
+func (doc mockVersionedDoc) Table() Table        { return "mock" }
+func (doc mockVersionedDoc) Id() Thing           { return Thing(fmt.Sprintf("mock:%d", doc.RecordID)) }
+func (doc mockVersionedDoc) VersionField() Field { return "v" }
+func (doc mockVersionedDoc) DocVersion() int64   { return doc.V }
+
+func (doc mockVersionedDoc) WithDocVersion(v int64) mockVersionedDoc {
+	doc.V = v
+	return doc
+}
+
+func TestDBSwap_Do(t *testing.T) {
+	t.Run("swapped", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{
+			"result": []interface{}{map[string]interface{}{"record_id": 1, "v": 3}},
+			"status": "OK",
+		}})
+		doc, err := SwapOn(mockVersionedDoc{RecordID: 1, V: 2}, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, mockVersionedDoc{RecordID: 1, V: 3}, doc)
+		assert.Equal(t, "UPDATE "+thingExpression("mock:1").String()+" CONTENT $doc WHERE (v = $version)", *db.sql)
+		assert.Equal(t, withThingVars(map[string]interface{}{
+			"doc":     mockVersionedDoc{RecordID: 1, V: 3},
+			"version": int64(2),
+		}, "mock:1"), *db.vars)
+	})
+	t.Run("version conflict", func(t *testing.T) {
+		db := newMockQueryDriver([]interface{}{map[string]interface{}{"result": []interface{}{}, "status": "OK"}})
+		_, err := SwapOn(mockVersionedDoc{RecordID: 1, V: 2}, db).Do()
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+func TestDBSelectAndSwap_Do(t *testing.T) {
+	t.Run("update ignores version changes", func(t *testing.T) {
+		swapped := map[string]interface{}{
+			"result": []interface{}{map[string]interface{}{"record_id": 1, "v": 8}},
+			"status": "OK",
+		}
+		db := newMockUpdateAllDriver([]mockVersionedDoc{{RecordID: 1, V: 7}}, nil, []interface{}{swapped})
+		doc, err := SelectAndSwap(NewQueryFrom(Table("mock")), func(doc mockVersionedDoc) mockVersionedDoc {
+			return doc.WithDocVersion(100)
+		}, db).Do()
+		require.NoError(t, err)
+		assert.Equal(t, mockVersionedDoc{RecordID: 1, V: 8}, doc)
+		assert.Equal(t, withThingVars(map[string]interface{}{
+			"doc":     mockVersionedDoc{RecordID: 1, V: 8},
+			"version": int64(7),
+		}, "mock:1"), *db.vars)
+	})
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	t.Run("retry until no conflict", func(t *testing.T) {
+		var calls int
+		err := RetryOnConflict(3, func() error {
+			calls++
+			if calls < 2 {
+				return ErrVersionConflict
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("give up", func(t *testing.T) {
+		var calls int
+		err := RetryOnConflict(3, func() error {
+			calls++
+			return fmt.Errorf("swap: %w", ErrVersionConflict)
+		})
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("other error", func(t *testing.T) {
+		var calls int
+		other := errors.New("other")
+		err := RetryOnConflict(3, func() error {
+			calls++
+			return other
+		})
+		assert.ErrorIs(t, err, other)
+		assert.Equal(t, 1, calls)
+	})
+	for _, attempts := range []int{0, -1} {
+		t.Run(fmt.Sprintf("%d attempts", attempts), func(t *testing.T) {
+			var calls int
+			err := RetryOnConflict(attempts, func() error {
+				calls++
+				return ErrVersionConflict
+			})
+			assert.ErrorIs(t, err, ErrVersionConflict)
+			assert.Equal(t, 1, calls)
+		})
+	}
+}